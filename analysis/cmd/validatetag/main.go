@@ -0,0 +1,16 @@
+// Command validatetag checks the struct tags read by the validate package. It's run by go vet:
+//
+//	go vet -vettool=$(which validatetag) ./...
+//
+// Custom validators registered with the RegistryBuilder are allowed with the
+// -validatetag.validators flag.
+package main
+
+import (
+	"github.com/craiggwilson/validate/analysis/validatetag"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(validatetag.Analyzer)
+}
@@ -0,0 +1,17 @@
+module github.com/craiggwilson/validate/analysis
+
+go 1.26.0
+
+require (
+	github.com/craiggwilson/validate v0.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.50.0
+)
+
+require (
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+)
+
+// Until the library has a release with ParseStructTag, the analyzer is built against the
+// copy of it in this repository.
+replace github.com/craiggwilson/validate => ../
@@ -0,0 +1,43 @@
+package a
+
+import (
+	"net"
+	"time"
+)
+
+type version struct{ major, minor int }
+
+func (v version) Compare(other version) int { return v.major - other.major }
+
+func (v version) ParseValidateArg(arg string) (version, error) { return version{}, nil }
+
+type color int
+
+type User struct {
+	Name     string            `validate:"notempty,maxlen(32)"`
+	Age      int               `validate:"gte(18),lt(150)"`
+	Tags     []string          `validate:"minlen(1),dive,notempty"`
+	Hosts    []string          `validate:"dive,ip|hostname"`
+	Labels   map[string]string `validate:"keys,items" validateKeys:"minlen(1)" validateItems:"maxlen(8)"`
+	Born     time.Time         `validate:"past"`
+	TTL      time.Duration     `validate:"gt(1m)"`
+	Addr     net.IP            `validate:"ipv4"`
+	Version  version           `validate:"gte(1.2)"`
+	Color    color             `validate:"in(1, 2, 3)"`
+	Nickname *string           `validate:"notnil|minlen(3)"`
+	Custom   string            `validate:"custom(1)"`
+	Ignored  string            `validate:"-"`
+	Other    string            `json:"other"`
+}
+
+type Bad struct {
+	Name    string         `validate:"minlen(3"`                    // want `Name: invalid validate tag "minlen\(3" at offset 8: expected "," or "\)"`
+	Unknown string         `validate:"notempty,bogus"`              // want `validate tag of Unknown: no validator factory found for bogus`
+	Len     string         `validate:"len(abc)"`                    // want `validate tag of Len: \(len\) argument must be an integer`
+	Gt      []int          `validate:"gt(3)"`                       // want `validate tag of Gt: \(gt\) only pointers to/or strings, bools, numbers, and time.Times are allowed`
+	Dive    int            `validate:"dive,gt(3)"`                  // want `validate tag of Dive: \(dive\) only pointers to/or arrays, slices, and maps are supported`
+	Items   []int          `validate:"items" validateItems:"gt(x)"` // want `validateItems tag of Items: \(gt\) .*`
+	Keys    []string       `validate:"keys"`                        // want `validate tag of Keys: \(keys\) only pointers to/or maps are supported`
+	Match   int            `validate:"match(^a$)"`                  // want `validate tag of Match: \(match\) .*`
+	Email   map[string]int `validate:"email"`                       // want `validate tag of Email: \(email\) .*`
+}
@@ -0,0 +1,373 @@
+// Package validatetag defines an Analyzer that checks the struct tags read by the validate
+// package, so that malformed tags, unknown validators, and validators applied to types they
+// don't support are reported when building rather than when a validator is first resolved.
+package validatetag
+
+import (
+	"go/ast"
+	"go/types"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/craiggwilson/validate"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check the struct tags read by the validate package
+
+The validatetag analyzer parses the validate struct tags, along with the tags read by the
+items and keys validators, with the grammar of validate.DefaultStructTagParser. It reports
+malformed tags, names of validators that aren't registered by default or listed with the
+-validators flag, and arguments or field types the builtin validators don't support.`
+
+// Analyzer checks the struct tags read by the validate package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "validatetag",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var (
+	tagName    string
+	validators string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&tagName, "tag", validate.DefaultStructTagName, "name of the struct tag holding the rules")
+	Analyzer.Flags.StringVar(&validators, "validators", "", "comma-separated list of custom validator names to allow")
+}
+
+// maxDepth limits how deep the tags of the items and keys validators are followed, which
+// could otherwise go on forever for recursive types.
+const maxDepth = 8
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	custom := make(map[string]bool)
+	for _, name := range strings.Split(validators, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			custom[name] = true
+		}
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, f := range n.(*ast.StructType).Fields.List {
+			if f.Tag == nil {
+				continue
+			}
+
+			raw, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				continue
+			}
+
+			t := pass.TypesInfo.TypeOf(f.Type)
+			if t == nil {
+				continue
+			}
+
+			c := &checker{pass: pass, field: f, tag: reflect.StructTag(raw), custom: custom}
+			c.checkTag(tagName, t, 0)
+		}
+	})
+
+	return nil, nil
+}
+
+type checker struct {
+	pass   *analysis.Pass
+	field  *ast.Field
+	tag    reflect.StructTag
+	custom map[string]bool
+}
+
+func (c *checker) fieldName() string {
+	if len(c.field.Names) > 0 {
+		return c.field.Names[0].Name
+	}
+
+	return types.ExprString(c.field.Type)
+}
+
+func (c *checker) report(tagName string, err error) {
+	c.pass.Reportf(c.field.Tag.Pos(), "%s tag of %s: %v", tagName, c.fieldName(), err)
+}
+
+// checkTag checks the named tag of the field as it applies to the type t.
+func (c *checker) checkTag(name string, t types.Type, depth int) {
+	tag, ok := c.tag.Lookup(name)
+	if !ok || tag == "-" {
+		return
+	}
+
+	st, err := validate.ParseStructTag(tag)
+	if err != nil {
+		if se, ok := err.(validate.TagSyntaxError); ok {
+			se.Field = c.fieldName()
+			se.TagName = name
+			c.pass.Reportf(c.field.Tag.Pos(), "%v", se)
+			return
+		}
+		c.report(name, err)
+		return
+	}
+
+	for _, section := range st.Sections {
+		for _, conjunction := range section.Disjunctions {
+			c.checkConjunction(name, conjunction, t, depth)
+		}
+	}
+}
+
+func (c *checker) checkConjunction(name string, conjunction []validate.StructTagRule, t types.Type, depth int) {
+	for _, r := range conjunction {
+		switch r.Name {
+		case "dive":
+			elem, ok := elemOf(t)
+			if !ok {
+				c.report(name, itemsNotAllowed(r))
+				return
+			}
+			if len(r.Args) > 0 {
+				c.report(name, validate.InvalidTagArgumentsError{Message: "no arguments are allowed", ValidatorName: r.Name, Args: r.Args})
+				return
+			}
+
+			for _, items := range r.Items {
+				c.checkConjunction(name, items, elem, depth)
+			}
+			return
+		case "items":
+			elem, ok := elemOf(t)
+			if !ok {
+				c.report(name, itemsNotAllowed(r))
+				continue
+			}
+
+			if depth < maxDepth {
+				c.checkTag(tagNameArg(r, "validateItems"), elem, depth+1)
+			}
+			continue
+		case "keys":
+			key, ok := keyOf(t)
+			if !ok {
+				c.report(name, validate.InvalidTagArgumentsError{Message: "only pointers to/or maps are supported", ValidatorName: r.Name, Args: r.Args})
+				continue
+			}
+
+			if depth < maxDepth {
+				c.checkTag(tagNameArg(r, "validateKeys"), key, depth+1)
+			}
+			continue
+		}
+
+		if c.custom[r.Name] {
+			continue
+		}
+
+		vf, err := validate.DefaultRegistry.LookupTagValidatorFactory(r.Name)
+		if err != nil {
+			c.report(name, err)
+			continue
+		}
+
+		if !typeChecked[r.Name] {
+			continue
+		}
+
+		rt, ok := reflectType(t)
+		if !ok {
+			continue
+		}
+
+		if _, err := vf.Create(validate.ResolutionContext{Type: rt}, r.Name, r.Args); err != nil {
+			c.report(name, err)
+		}
+	}
+}
+
+func itemsNotAllowed(r validate.StructTagRule) error {
+	return validate.InvalidTagArgumentsError{Message: "only pointers to/or arrays, slices, and maps are supported", ValidatorName: r.Name, Args: r.Args}
+}
+
+func tagNameArg(r validate.StructTagRule, def string) string {
+	if len(r.Args) == 1 {
+		return r.Args[0]
+	}
+
+	return def
+}
+
+// typeChecked holds the default validators whose rules depend only on the type they apply to
+// and their arguments, so that their factories can check a type built to stand in for it.
+var typeChecked = map[string]bool{
+	"after":         true,
+	"before":        true,
+	"cidr":          true,
+	"cidr_contains": true,
+	"email":         true,
+	"empty":         true,
+	"eq":            true,
+	"fqdn":          true,
+	"future":        true,
+	"gt":            true,
+	"gte":           true,
+	"hostname":      true,
+	"hostport":      true,
+	"in":            true,
+	"ip":            true,
+	"ipv4":          true,
+	"ipv6":          true,
+	"len":           true,
+	"lt":            true,
+	"lte":           true,
+	"mac":           true,
+	"match":         true,
+	"maxlen":        true,
+	"minlen":        true,
+	"neq":           true,
+	"nil":           true,
+	"notempty":      true,
+	"notnil":        true,
+	"notzero":       true,
+	"past":          true,
+	"port":          true,
+	"regex":         true,
+	"ulid":          true,
+	"uri":           true,
+	"url":           true,
+	"uuid":          true,
+	"within":        true,
+	"zero":          true,
+}
+
+func elemOf(t types.Type) (types.Type, bool) {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return elemOf(u.Elem())
+	case *types.Array:
+		return u.Elem(), true
+	case *types.Slice:
+		return u.Elem(), true
+	case *types.Map:
+		return u.Elem(), true
+	}
+
+	return nil, false
+}
+
+func keyOf(t types.Type) (types.Type, bool) {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return keyOf(u.Elem())
+	case *types.Map:
+		return u.Key(), true
+	}
+
+	return nil, false
+}
+
+// wellKnownTypes are the named types the validate package treats specially.
+var wellKnownTypes = map[string]reflect.Type{
+	"net.HardwareAddr": reflect.TypeOf(net.HardwareAddr(nil)),
+	"net.IP":           reflect.TypeOf(net.IP(nil)),
+	"net.IPNet":        reflect.TypeOf(net.IPNet{}),
+	"time.Duration":    reflect.TypeOf(time.Duration(0)),
+	"time.Time":        reflect.TypeOf(time.Time{}),
+}
+
+var basicTypes = map[types.BasicKind]reflect.Type{
+	types.Bool:       reflect.TypeOf(false),
+	types.Int:        reflect.TypeOf(int(0)),
+	types.Int8:       reflect.TypeOf(int8(0)),
+	types.Int16:      reflect.TypeOf(int16(0)),
+	types.Int32:      reflect.TypeOf(int32(0)),
+	types.Int64:      reflect.TypeOf(int64(0)),
+	types.Uint:       reflect.TypeOf(uint(0)),
+	types.Uint8:      reflect.TypeOf(uint8(0)),
+	types.Uint16:     reflect.TypeOf(uint16(0)),
+	types.Uint32:     reflect.TypeOf(uint32(0)),
+	types.Uint64:     reflect.TypeOf(uint64(0)),
+	types.Uintptr:    reflect.TypeOf(uintptr(0)),
+	types.Float32:    reflect.TypeOf(float32(0)),
+	types.Float64:    reflect.TypeOf(float64(0)),
+	types.Complex64:  reflect.TypeOf(complex64(0)),
+	types.Complex128: reflect.TypeOf(complex128(0)),
+	types.String:     reflect.TypeOf(""),
+}
+
+// reflectType builds a reflect.Type standing in for the t as far as the rules of the validators
+// in typeChecked are concerned. Types whose Compare or ParseValidateArg methods would change
+// those rules can't be stood in for.
+func reflectType(t types.Type) (reflect.Type, bool) {
+	switch tt := t.(type) {
+	case *types.Named:
+		obj := tt.Obj()
+		if obj.Pkg() != nil {
+			if rt, ok := wellKnownTypes[obj.Pkg().Path()+"."+obj.Name()]; ok {
+				return rt, true
+			}
+		}
+		if hasMethod(tt, "Compare") || hasMethod(tt, "ParseValidateArg") {
+			return nil, false
+		}
+		return reflectType(tt.Underlying())
+	case *types.Basic:
+		rt, ok := basicTypes[tt.Kind()]
+		return rt, ok
+	case *types.Pointer:
+		elem, ok := reflectType(tt.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.PtrTo(elem), true
+	case *types.Slice:
+		elem, ok := reflectType(tt.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.SliceOf(elem), true
+	case *types.Array:
+		elem, ok := reflectType(tt.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.ArrayOf(int(tt.Len()), elem), true
+	case *types.Map:
+		key, ok := reflectType(tt.Key())
+		if !ok {
+			return nil, false
+		}
+		elem, ok := reflectType(tt.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.MapOf(key, elem), true
+	case *types.Chan:
+		elem, ok := reflectType(tt.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.ChanOf(reflect.BothDir, elem), true
+	case *types.Struct:
+		return reflect.TypeOf(struct{}{}), true
+	case *types.Interface:
+		return reflect.TypeOf((*interface{})(nil)).Elem(), true
+	case *types.Signature:
+		return reflect.TypeOf(func() {}), true
+	}
+
+	return nil, false
+}
+
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
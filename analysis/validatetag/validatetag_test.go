@@ -0,0 +1,16 @@
+package validatetag_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/validate/analysis/validatetag"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := validatetag.Analyzer.Flags.Set("validators", "custom"); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.Run(t, analysistest.TestData(), validatetag.Analyzer, "a")
+}
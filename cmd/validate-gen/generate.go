@@ -0,0 +1,564 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/craiggwilson/validate"
+)
+
+const validatePackage = "github.com/craiggwilson/validate"
+
+// generate generates the validators for the named struct types declared in the files.
+func generate(files []string, types []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	var pkg string
+	specs := make(map[string]*ast.TypeSpec)
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if pkg == "" {
+			pkg = f.Name.Name
+		} else if f.Name.Name != pkg {
+			return nil, fmt.Errorf("files belong to packages %s and %s", pkg, f.Name.Name)
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				specs[ts.Name.Name] = ts
+			}
+			return true
+		})
+	}
+
+	g := &generator{}
+	for _, name := range types {
+		ts, ok := specs[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found", name)
+		}
+
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not a struct", name)
+		}
+
+		if err := g.generateType(name, st); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by validate-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import (\n")
+	if len(g.regexps) > 0 {
+		fmt.Fprintf(&buf, "\t\"regexp\"\n\n")
+	}
+	fmt.Fprintf(&buf, "\t%q\n)\n", validatePackage)
+	if len(g.regexps) > 0 {
+		fmt.Fprintf(&buf, "\nvar (\n")
+		for _, re := range g.regexps {
+			fmt.Fprintf(&buf, "\t%s = regexp.MustCompile(%q)\n", re.name, re.pattern)
+		}
+		fmt.Fprintf(&buf, ")\n")
+	}
+	buf.Write(g.buf.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	regexps []regexpVar
+}
+
+// regexpVar is a package level variable holding a compiled pattern for the match validator.
+type regexpVar struct {
+	name    string
+	pattern string
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// field is a struct field with validate struct tags.
+type field struct {
+	typeName string
+	name     string
+	exported bool
+	typ      fieldType
+	checks   []check
+}
+
+// fieldType classifies the type of a field into what the generated code handles alike.
+type fieldType struct {
+	// class is one of bool, int, uint, float, string, array, slice, or map.
+	class string
+	// name is the name of a predeclared basic type.
+	name string
+}
+
+// check is the generated code for a single rule of a struct tag.
+type check struct {
+	validatorName string
+	// failed is the condition under which the rule fails.
+	failed string
+	// args is the expression for the Args of the error, if any.
+	args    string
+	message string
+}
+
+func (g *generator) generateType(typeName string, st *ast.StructType) error {
+	var fields []*field
+	for _, f := range st.Fields.List {
+		tag, ok := validateTag(f)
+		if !ok {
+			continue
+		}
+
+		if len(f.Names) == 0 {
+			return fmt.Errorf("%s: embedded fields are not supported", typeName)
+		}
+
+		typ, ok := fieldTypeOf(f.Type)
+		if !ok {
+			return fmt.Errorf("%s.%s: only fields of the predeclared basic types, arrays, slices, and maps are supported", typeName, f.Names[0].Name)
+		}
+
+		for _, n := range f.Names {
+			fld := &field{typeName: typeName, name: n.Name, exported: n.IsExported(), typ: typ}
+			if err := g.parseChecks(fld, tag); err != nil {
+				return err
+			}
+
+			fields = append(fields, fld)
+		}
+	}
+
+	g.printf("\n// ValidateGenerated implements validate.GeneratedValidator.\n")
+	g.printf("func (x *%s) ValidateGenerated(ctx validate.Context) (error, error) {\n", typeName)
+	if len(fields) == 0 {
+		g.printf("return nil, nil\n}\n")
+		return nil
+	}
+
+	g.printf("var errs []error\n")
+	for _, f := range fields {
+		g.printf("if err := ctx.Context().Err(); err != nil {\n")
+		g.printf("return validate.CanceledError{Err: err}, nil\n")
+		g.printf("}\n")
+		g.printf("if ctx.SelectsField(%q) {\n", f.name)
+		g.printf("if err := x.%s(ctx); err != nil {\n", f.methodName())
+		g.printf("errs = append(errs, err)\n")
+		g.printf("if ctx.Options.StopOnError {\n")
+		g.printf("return &validate.ValidationErrors{Op: \"and\", Errors: errs}, nil\n")
+		g.printf("}\n}\n}\n")
+	}
+	g.printf("if len(errs) > 0 {\n")
+	g.printf("return &validate.ValidationErrors{Op: \"and\", Errors: errs}, nil\n")
+	g.printf("}\n\nreturn nil, nil\n}\n")
+
+	for _, f := range fields {
+		g.generateField(f)
+	}
+
+	return nil
+}
+
+// generateField generates a method validating the field, producing the same errors as a Field
+// validator wrapping the conjunction of its rules.
+func (g *generator) generateField(f *field) {
+	path := fmt.Sprintf("validate.Path{{Kind: validate.FieldSegment, Field: %q}}", f.name)
+
+	g.printf("\nfunc (x *%s) %s(ctx validate.Context) error {\n", f.typeName, f.methodName())
+	if len(f.checks) == 1 {
+		c := f.checks[0]
+		g.printf("if %s {\n", c.failed)
+		g.printf("return %s\n", f.fieldError(c, path))
+		g.printf("}\n\nreturn nil\n}\n")
+		return
+	}
+
+	g.printf("var errs []error\n")
+	for _, c := range f.checks {
+		g.printf("if %s {\n", c.failed)
+		g.printf("errs = append(errs, %s)\n", f.fieldError(c, ""))
+		g.printf("if ctx.Options.StopOnError {\n")
+		g.printf("return &validate.ValidationErrors{Path: %s, Op: \"and\", Errors: errs}\n", path)
+		g.printf("}\n}\n")
+	}
+	g.printf("if len(errs) > 0 {\n")
+	g.printf("return &validate.ValidationErrors{Path: %s, Op: \"and\", Errors: errs}\n", path)
+	g.printf("}\n\nreturn nil\n}\n")
+}
+
+func (f *field) methodName() string {
+	return "validateGenerated_" + f.name
+}
+
+func (f *field) value() string {
+	return "x." + f.name
+}
+
+// fieldError returns the expression for the error of the failed check. Like the validate
+// package, it leaves out the values of unexported fields.
+func (f *field) fieldError(c check, path string) string {
+	var sb strings.Builder
+	sb.WriteString("&validate.FieldError{")
+	if path != "" {
+		fmt.Fprintf(&sb, "Path: %s, ", path)
+	}
+	fmt.Fprintf(&sb, "ValidatorName: %q, ", c.validatorName)
+	if c.args != "" {
+		fmt.Fprintf(&sb, "Args: %s, ", c.args)
+	}
+	if f.exported {
+		fmt.Fprintf(&sb, "Value: %s, ", f.value())
+	}
+	fmt.Fprintf(&sb, "Message: %q}", c.message)
+	return sb.String()
+}
+
+func validateTag(f *ast.Field) (string, bool) {
+	if f.Tag == nil {
+		return "", false
+	}
+
+	raw, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+
+	tag, ok := reflect.StructTag(raw).Lookup(validate.DefaultStructTagName)
+	if !ok || tag == "-" {
+		return "", false
+	}
+
+	return tag, true
+}
+
+var basicClasses = map[string]string{
+	"bool":    "bool",
+	"int":     "int",
+	"int8":    "int",
+	"int16":   "int",
+	"int32":   "int",
+	"int64":   "int",
+	"rune":    "int",
+	"uint":    "uint",
+	"uint8":   "uint",
+	"uint16":  "uint",
+	"uint32":  "uint",
+	"uint64":  "uint",
+	"uintptr": "uint",
+	"byte":    "uint",
+	"float32": "float",
+	"float64": "float",
+	"string":  "string",
+}
+
+func fieldTypeOf(expr ast.Expr) (fieldType, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		class, ok := basicClasses[t.Name]
+		if !ok || t.Obj != nil {
+			return fieldType{}, false
+		}
+		return fieldType{class: class, name: t.Name}, true
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return fieldType{class: "slice"}, true
+		}
+		return fieldType{class: "array"}, true
+	case *ast.MapType:
+		return fieldType{class: "map"}, true
+	}
+
+	return fieldType{}, false
+}
+
+func (g *generator) parseChecks(f *field, tag string) error {
+	st, err := validate.ParseStructTag(tag)
+	if err != nil {
+		return fmt.Errorf("%s.%s: %v", f.typeName, f.name, err)
+	}
+
+	if st.CustomMessage != "" || len(st.Sections) != 1 || len(st.Sections[0].Groups) > 0 || len(st.Sections[0].Disjunctions) != 1 {
+		return fmt.Errorf("%s.%s: groups, disjunctions, and custom messages are not supported", f.typeName, f.name)
+	}
+
+	for _, r := range st.Sections[0].Disjunctions[0] {
+		if r.Severity != validate.SeverityUnspecified {
+			return fmt.Errorf("%s.%s: severities are not supported", f.typeName, f.name)
+		}
+
+		c, err := g.check(f, r)
+		if err != nil {
+			return fmt.Errorf("%s.%s: (%s) %v", f.typeName, f.name, r.Name, err)
+		}
+
+		f.checks = append(f.checks, c)
+	}
+
+	return nil
+}
+
+var cmpConditions = map[string]struct {
+	failed  string
+	message string
+}{
+	"eq":  {"%s != %s", "must be equal to %v"},
+	"neq": {"%s == %s", "must not be equal to %v"},
+	"gt":  {"%s <= %s", "must be greater than %v"},
+	"gte": {"%s < %s", "must be greater than or equal to %v"},
+	"lt":  {"%s >= %s", "must be less than %v"},
+	"lte": {"%s > %s", "must be less than or equal to %v"},
+}
+
+// floatConditions are the conditions for floats where NaN must fail like it does in the
+// validate package.
+var floatConditions = map[string]string{
+	"lt":  "!(%s < %s)",
+	"lte": "!(%s <= %s)",
+}
+
+func (g *generator) check(f *field, r validate.StructTagRule) (check, error) {
+	c := check{validatorName: r.Name}
+	v := f.value()
+
+	switch r.Name {
+	case "empty", "notempty", "len", "minlen", "maxlen":
+		if f.typ.class != "string" && !f.typ.isCollection() {
+			return c, fmt.Errorf("only strings, arrays, slices, and maps are supported")
+		}
+
+		if r.Name == "empty" || r.Name == "notempty" {
+			if len(r.Args) > 0 {
+				return c, fmt.Errorf("no arguments are allowed")
+			}
+			if r.Name == "empty" {
+				c.failed, c.message = fmt.Sprintf("len(%s) != 0", v), "must be empty"
+			} else {
+				c.failed, c.message = fmt.Sprintf("len(%s) == 0", v), "must not be empty"
+			}
+			return c, nil
+		}
+
+		if len(r.Args) != 1 {
+			return c, fmt.Errorf("exactly one argument is required")
+		}
+		n, err := strconv.Atoi(r.Args[0])
+		if err != nil {
+			return c, fmt.Errorf("the argument must be an integer")
+		}
+
+		c.args = fmt.Sprintf("[]interface{}{%d}", n)
+		switch r.Name {
+		case "len":
+			c.failed, c.message = fmt.Sprintf("len(%s) != %d", v, n), fmt.Sprintf("must be of length %d", n)
+		case "minlen":
+			c.failed, c.message = fmt.Sprintf("len(%s) < %d", v, n), fmt.Sprintf("must have min length %d", n)
+		case "maxlen":
+			c.failed, c.message = fmt.Sprintf("len(%s) > %d", v, n), fmt.Sprintf("must have max length %d", n)
+		}
+		return c, nil
+	case "eq", "neq", "gt", "gte", "lt", "lte":
+		if len(r.Args) != 1 {
+			return c, fmt.Errorf("exactly one argument is required")
+		}
+		if f.typ.class == "bool" && r.Name != "eq" && r.Name != "neq" {
+			return c, fmt.Errorf("only eq and neq are supported for bools")
+		}
+
+		lit, arg, val, err := f.typ.literal(r.Args[0])
+		if err != nil {
+			return c, err
+		}
+
+		cond := cmpConditions[r.Name]
+		failed := cond.failed
+		if fc, ok := floatConditions[r.Name]; ok && f.typ.class == "float" {
+			failed = fc
+		}
+
+		c.failed = fmt.Sprintf(failed, f.typ.operand(v), lit)
+		c.args = fmt.Sprintf("[]interface{}{%s}", arg)
+		c.message = fmt.Sprintf(cond.message, val)
+		return c, nil
+	case "in":
+		if len(r.Args) == 0 {
+			return c, fmt.Errorf("at least one argument is required")
+		}
+
+		var conds, args []string
+		var vals []interface{}
+		for _, a := range r.Args {
+			lit, arg, val, err := f.typ.literal(a)
+			if err != nil {
+				return c, err
+			}
+
+			conds = append(conds, fmt.Sprintf("%s != %s", f.typ.operand(v), lit))
+			args = append(args, arg)
+			vals = append(vals, val)
+		}
+
+		c.failed = strings.Join(conds, " && ")
+		c.args = fmt.Sprintf("[]interface{}{%s}", strings.Join(args, ", "))
+		c.message = fmt.Sprintf("must be one of %v", vals)
+		return c, nil
+	case "match":
+		if f.typ.class != "string" {
+			return c, fmt.Errorf("only strings are supported")
+		}
+		if len(r.Args) != 1 {
+			return c, fmt.Errorf("exactly one argument is required")
+		}
+		if _, err := regexp.Compile(r.Args[0]); err != nil {
+			return c, err
+		}
+
+		name := fmt.Sprintf("_%s_%s_regexp", f.typeName, f.name)
+		for i := 1; g.hasRegexp(name); i++ {
+			name = fmt.Sprintf("_%s_%s_regexp%d", f.typeName, f.name, i)
+		}
+		g.regexps = append(g.regexps, regexpVar{name: name, pattern: r.Args[0]})
+
+		c.failed = fmt.Sprintf("!%s.MatchString(%s)", name, v)
+		c.args = fmt.Sprintf("[]interface{}{%q}", r.Args[0])
+		c.message = fmt.Sprintf("must match %q", r.Args[0])
+		return c, nil
+	case "zero", "notzero":
+		if len(r.Args) > 0 {
+			return c, fmt.Errorf("no arguments are allowed")
+		}
+
+		var zero, text string
+		switch f.typ.class {
+		case "string":
+			zero, text = `""`, ""
+		case "int", "uint":
+			zero, text = "0", "0"
+		case "bool":
+			zero, text = "false", "false"
+		case "slice":
+			zero, text = "nil", "[]"
+		case "map":
+			zero, text = "nil", "map[]"
+		default:
+			return c, fmt.Errorf("only strings, bools, integers, slices, and maps are supported")
+		}
+
+		if r.Name == "zero" {
+			c.failed, c.message = fmt.Sprintf("%s != %s", v, zero), fmt.Sprintf("must be %q", text)
+		} else {
+			c.failed, c.message = fmt.Sprintf("%s == %s", v, zero), fmt.Sprintf("must not be %q", text)
+		}
+		return c, nil
+	case "nil", "notnil":
+		if f.typ.class != "slice" && f.typ.class != "map" {
+			return c, fmt.Errorf("only slices and maps are supported")
+		}
+		if len(r.Args) > 0 {
+			return c, fmt.Errorf("no arguments are allowed")
+		}
+
+		if r.Name == "nil" {
+			c.failed, c.message = fmt.Sprintf("%s != nil", v), "must be nil"
+		} else {
+			c.failed, c.message = fmt.Sprintf("%s == nil", v), "must not be nil"
+		}
+		return c, nil
+	}
+
+	return c, fmt.Errorf("validator is not supported")
+}
+
+func (g *generator) hasRegexp(name string) bool {
+	for _, re := range g.regexps {
+		if re.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t fieldType) isCollection() bool {
+	return t.class == "array" || t.class == "slice" || t.class == "map"
+}
+
+// operand returns the expression comparing the value the way the validate package does, which
+// widens numbers to 64 bits.
+func (t fieldType) operand(v string) string {
+	var wide string
+	switch t.class {
+	case "int":
+		wide = "int64"
+	case "uint":
+		wide = "uint64"
+	case "float":
+		wide = "float64"
+	default:
+		return v
+	}
+
+	if t.name == wide {
+		return v
+	}
+
+	return wide + "(" + v + ")"
+}
+
+// literal parses the argument like the validate package does, returning the constant for
+// comparisons, the expression for the Args of an error, and the parsed value.
+func (t fieldType) literal(arg string) (string, string, interface{}, error) {
+	switch t.class {
+	case "bool":
+		v, err := strconv.ParseBool(arg)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return strconv.FormatBool(v), strconv.FormatBool(v), v, nil
+	case "int":
+		v, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return strconv.FormatInt(v, 10), fmt.Sprintf("int64(%d)", v), v, nil
+	case "uint":
+		v, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return strconv.FormatUint(v, 10), fmt.Sprintf("uint64(%d)", v), v, nil
+	case "float":
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return "", "", nil, fmt.Errorf("only finite numbers are supported")
+		}
+		lit := strconv.FormatFloat(v, 'g', -1, 64)
+		return lit, "float64(" + lit + ")", v, nil
+	case "string":
+		return strconv.Quote(arg), strconv.Quote(arg), arg, nil
+	}
+
+	return "", "", nil, fmt.Errorf("only strings, bools, and numbers are supported")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate([]string{"../../validate_test.go"}, []string{"generatedAccount"})
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	expected, err := ioutil.ReadFile("../../generatedaccount_validate_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(src, expected) {
+		t.Fatalf("expected the generated code to match generatedaccount_validate_test.go; run go generate")
+	}
+}
+
+func TestGenerate_Unsupported(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "types.go")
+	err = ioutil.WriteFile(file, []byte("package types\n\n"+
+		"type Disjunction struct {\n\tName string `validate:\"empty|minlen(3)\"`\n}\n\n"+
+		"type Pointer struct {\n\tName *string `validate:\"notnil\"`\n}\n\n"+
+		"type Unknown struct {\n\tName string `validate:\"email\"`\n}\n\n"+
+		"type Mismatch struct {\n\tAge int `validate:\"match(^[0-9]+$)\"`\n}\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		typeName string
+		err      string
+	}{
+		{"Disjunction", "Disjunction.Name: groups, disjunctions, and custom messages are not supported"},
+		{"Pointer", "Pointer.Name: only fields of the predeclared basic types, arrays, slices, and maps are supported"},
+		{"Unknown", "Unknown.Name: (email) validator is not supported"},
+		{"Mismatch", "Mismatch.Age: (match) only strings are supported"},
+		{"Missing", "type Missing not found"},
+	} {
+		t.Run(tc.typeName, func(t *testing.T) {
+			_, err := generate([]string{file}, []string{tc.typeName})
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error %q, but got %v", tc.err, err)
+			}
+		})
+	}
+}
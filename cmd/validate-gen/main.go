@@ -0,0 +1,114 @@
+// Command validate-gen generates reflection-free validators from the validate struct tags of
+// named struct types. For each type T, it generates a method
+//
+//	func (x *T) ValidateGenerated(ctx validate.Context) (error, error)
+//
+// implementing validate.GeneratedValidator, which registries built with UseGeneratedValidators
+// prefer over building a validator by reflecting over the fields of T. The generated code
+// produces the same errors. Registries, including validate.DefaultRegistry, don't use it unless
+// they opt in, so the generated code must be regenerated whenever the tags change.
+//
+// Typically, it's invoked with go generate:
+//
+//	//go:generate go run github.com/craiggwilson/validate/cmd/validate-gen -type User
+//
+// Only the builtin validators empty, notempty, len, minlen, maxlen, eq, neq, gt, gte, lt, lte,
+// in, match, zero, notzero, nil, and notnil are supported, on fields of the predeclared basic
+// types, slices, arrays, and maps. Tags using groups, disjunctions, dives, severities, or custom
+// messages, and types registering their own tag validators under those names, aren't
+// supported; such types must continue to be validated by reflection.
+//
+// Usage:
+//
+//	validate-gen -type T[,T...] [-output file] [dir | files...]
+//
+// The types are looked for in the non-test Go files of the directory, which defaults to the
+// current one, or in the named files. The output defaults to t_validate.go next to them, where
+// t is the first type in lower case, with a _test.go suffix when the files are test files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+	output    = flag.String("output", "", "output file name; default <dir>/<type>_validate.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of validate-gen:\n")
+	fmt.Fprintf(os.Stderr, "\tvalidate-gen -type T[,T...] [-output file] [dir | files...]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(strings.Split(*typeNames, ","), *output, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "validate-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(types []string, output string, args []string) error {
+	files, err := sourceFiles(args)
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(files, types)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		name := strings.ToLower(types[0]) + "_validate.go"
+		if strings.HasSuffix(files[0], "_test.go") {
+			name = strings.ToLower(types[0]) + "_validate_test.go"
+		}
+		output = filepath.Join(filepath.Dir(files[0]), name)
+	}
+
+	return ioutil.WriteFile(output, src, 0644)
+}
+
+// sourceFiles returns the files named by the args, or the non-test Go files of the directory
+// named by them.
+func sourceFiles(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	if len(args) > 1 || strings.HasSuffix(args[0], ".go") {
+		return args, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(args[0], "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, m := range matches {
+		if !strings.HasSuffix(m, "_test.go") {
+			files = append(files, m)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", args[0])
+	}
+
+	return files, nil
+}
@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var tError = reflect.TypeOf((*error)(nil)).Elem()
+
+// compareFunc compares a value with another, returning a negative number, 0, or a positive
+// number when the value is less than, equal to, or greater than the other.
+type compareFunc func(val reflect.Value, other reflect.Value) (int, error)
+
+// comparerOf returns the compareFunc for values of the type, resolving its Compare method, if
+// it has one, once rather than on each comparison.
+func comparerOf(t reflect.Type) compareFunc {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if m, ok := compareMethod(t); ok {
+		return func(val reflect.Value, other reflect.Value) (int, error) {
+			return callCompare(m, val, other)
+		}
+	}
+
+	return cmpValues
+}
+
+// compareMethod returns the Compare method of the type, if it has one of the expected form.
+func compareMethod(t reflect.Type) (reflect.Method, bool) {
+	if t == tTime {
+		return reflect.Method{}, false
+	}
+
+	m, ok := reflect.PtrTo(t).MethodByName("Compare")
+	if !ok ||
+		m.Type.NumIn() != 2 || m.Type.In(1) != t ||
+		m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Int {
+		return reflect.Method{}, false
+	}
+
+	return m, true
+}
+
+// hasCompareMethod indicates whether the type has a Compare method of the expected form.
+func hasCompareMethod(t reflect.Type) bool {
+	_, ok := compareMethod(t)
+	return ok
+}
+
+// hasParseArgMethod indicates whether the type has a ParseValidateArg method of the expected form.
+func hasParseArgMethod(t reflect.Type) bool {
+	m, ok := reflect.PtrTo(t).MethodByName("ParseValidateArg")
+	return ok &&
+		m.Type.NumIn() == 2 && m.Type.In(1).Kind() == reflect.String &&
+		m.Type.NumOut() == 2 && m.Type.Out(0) == t && m.Type.Out(1) == tError
+}
+
+// callCompare compares the values with the Compare method of their type.
+func callCompare(m reflect.Method, val reflect.Value, other reflect.Value) (int, error) {
+	t := m.Type.In(1)
+	if other.Type() != t && other.Kind() == t.Kind() && other.Type().ConvertibleTo(t) {
+		other = other.Convert(t)
+	}
+	if val.Type() != t || other.Type() != t {
+		return 0, fmt.Errorf("incompatible types for comparision: %s and %s", val.Type(), other.Type())
+	}
+	if !val.CanInterface() || !other.CanInterface() {
+		return 0, fmt.Errorf("cannot compare an unexported %s", val.Type())
+	}
+
+	ptr := reflect.New(t)
+	ptr.Elem().Set(val)
+	r := m.Func.Call([]reflect.Value{ptr, other})
+	return int(r[0].Int()), nil
+}
+
+// callParseArg parses the arg with the ParseValidateArg method of the type.
+func callParseArg(t reflect.Type, arg string) (interface{}, error) {
+	m := reflect.New(t).MethodByName("ParseValidateArg")
+	r := m.Call([]reflect.Value{reflect.ValueOf(arg).Convert(m.Type().In(0))})
+	if err, _ := r[1].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	return r[0].Interface(), nil
+}
+
+// isPrimitive indicates whether arguments for the type can be parsed by its kind alone.
+func isPrimitive(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
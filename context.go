@@ -1,12 +1,62 @@
 package validate
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
 // Context holds all the information required for validating an object.
 type Context struct {
-	Options  *Options
+	Options *Options
 
 	Parent *Context
 	Value  reflect.Value
+
+	ctx  context.Context
+	path Path
+}
+
+// Context returns the context.Context validation is performed with, which carries
+// deadlines, cancellation, and request-scoped values.
+func (ctx Context) Context() context.Context {
+	if ctx.ctx == nil {
+		return context.Background()
+	}
+
+	return ctx.ctx
+}
+
+// SelectsField indicates whether the options select the named field of the value for
+// validation. It's used by generated validators to honor field masks and exclusions.
+func (ctx Context) SelectsField(name string) bool {
+	if !ctx.Options.masksFields() {
+		return true
+	}
+
+	return ctx.Options.selects(append(ctx.path[:len(ctx.path):len(ctx.path)], fieldSegment(name)))
+}
+
+// selects sets the path of the ctx to the segment within the parent's value, and indicates
+// whether the options select it for validation.
+func (ctx *Context) selects(seg PathSegment) bool {
+	if !ctx.Options.masksFields() {
+		return true
+	}
+
+	ctx.path = append(ctx.Parent.path[:len(ctx.Parent.path):len(ctx.Parent.path)], seg)
+	return ctx.Options.selects(ctx.path)
 }
 
+// canceled returns a CanceledError once the context.Context is done.
+func (ctx Context) canceled() error {
+	if ctx.ctx == nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.ctx.Done():
+		return CanceledError{Err: ctx.ctx.Err()}
+	default:
+		return nil
+	}
+}
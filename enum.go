@@ -0,0 +1,109 @@
+package validate
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var tTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Enum requires the value to be one of the values. The values are listed in messages by their
+// names when they implement fmt.Stringer.
+func Enum(values ...interface{}) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		val := indirect(ctx.Value)
+		if val.Kind() == reflect.Ptr {
+			return newFieldErrorf(ctx, "enum", values, "must be one of %v", values), nil
+		}
+
+		for _, v := range values {
+			if equalValues(val, reflect.ValueOf(v)) {
+				return nil, nil
+			}
+		}
+
+		return newFieldErrorf(ctx, "enum", values, "must be one of %v", values), nil
+	})
+}
+
+// valuesOf returns the values returned by a Values method of the type, which must return a
+// slice of the type.
+func valuesOf(t reflect.Type) ([]interface{}, bool) {
+	m, ok := t.MethodByName("Values")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 {
+		return nil, false
+	}
+
+	out := m.Type.Out(0)
+	if out.Kind() != reflect.Slice || out.Elem() != t {
+		return nil, false
+	}
+
+	slice := m.Func.Call([]reflect.Value{reflect.Zero(t)})[0]
+
+	var values []interface{}
+	for i := 0; i < slice.Len(); i++ {
+		values = append(values, slice.Index(i).Interface())
+	}
+
+	return values, true
+}
+
+// enumSubset returns the values named by the names, either parsed with the type's
+// encoding.TextUnmarshaler implementation or matched against the values' fmt.Sprint forms.
+func enumSubset(t reflect.Type, values []interface{}, names []string) ([]interface{}, error) {
+	var subset []interface{}
+	for _, name := range names {
+		v, err := enumValue(t, values, name)
+		if err != nil {
+			return nil, err
+		}
+
+		subset = append(subset, v)
+	}
+
+	return subset, nil
+}
+
+func enumValue(t reflect.Type, values []interface{}, name string) (interface{}, error) {
+	var named interface{}
+	if reflect.PtrTo(t).Implements(tTextUnmarshaler) {
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(name)); err != nil {
+			return nil, err
+		}
+		named = ptr.Elem().Interface()
+	}
+
+	for _, v := range values {
+		if named != nil && equalValues(reflect.ValueOf(named), reflect.ValueOf(v)) || named == nil && fmt.Sprint(v) == name {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%q is not one of %v", name, values)
+}
+
+func isEnumAllowed(ctx ResolutionContext, name string, args []string) ([]interface{}, error) {
+	t := ctx.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	values, err := ctx.registry.LookupEnum(t)
+	if err != nil {
+		return nil, InvalidTagArgumentsError{Message: err.Error(), ValidatorName: name, Args: args}
+	}
+
+	if len(args) == 0 {
+		return values, nil
+	}
+
+	subset, err := enumSubset(t, values, args)
+	if err != nil {
+		return nil, InvalidTagArgumentsError{Message: "arguments must name values: " + err.Error(), ValidatorName: name, Args: args}
+	}
+
+	return subset, nil
+}
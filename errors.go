@@ -3,12 +3,16 @@ package validate
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 func mergeWarning(err error, warning error) error {
 	if err == nil && warning == nil {
 		return nil
 	}
+	if isCanceled(err) {
+		return err
+	}
 
 	errs := []error{}
 	if err != nil {
@@ -17,51 +21,255 @@ func mergeWarning(err error, warning error) error {
 	if warning != nil {
 		errs = append(errs, warning)
 	}
-	return newError(mergeErrorMessages(errs, "and"))
+	return &ValidationErrors{Op: "and", Errors: errs}
 }
 
-func mergeErrorMessages(errs []error, sep string) string {
-	paddedSep := fmt.Sprintf(" %s ", sep)
-	if len(errs) == 0 {
-		panic("must provide at least one error")
+func mergeIntoWarningsAndErrors(warnings []error, errs []error, op string) (error, error) {
+	var warning error
+	if len(warnings) > 0 {
+		warning = &ValidationErrors{Op: op, Errors: warnings}
 	}
-	errmsg := errs[0].Error()
-	for i := 1; i < len(errs); i++ {
-		errmsg += paddedSep + errs[i].Error()
+
+	if len(errs) > 0 {
+		return &ValidationErrors{Op: op, Errors: errs}, warning
 	}
 
-	return errmsg
+	return nil, warning
 }
 
-func mergeIntoWarningsAndErrors(warnings []error, errs []error, sep string) (error, error) {
-	var warning error
-	if len(warnings) > 0 {
-		warning = newError(mergeErrorMessages(warnings, sep))
+func newError(msg string) *FieldError {
+	return &FieldError{Message: msg}
+}
+
+func newErrorf(msg string, args ...interface{}) *FieldError {
+	return &FieldError{Message: fmt.Sprintf(msg, args...)}
+}
+
+func newFieldError(ctx Context, validatorName string, args []interface{}, msg string) *FieldError {
+	return &FieldError{
+		ValidatorName: validatorName,
+		Args:          args,
+		Value:         interfaceOf(ctx.Value),
+		Message:       msg,
 	}
+}
 
-	if len(errs) > 0 {
-		return newError(mergeErrorMessages(errs, sep)), warning
+func newFieldErrorf(ctx Context, validatorName string, args []interface{}, msg string, msgArgs ...interface{}) *FieldError {
+	return newFieldError(ctx, validatorName, args, fmt.Sprintf(msg, msgArgs...))
+}
+
+func interfaceOf(val reflect.Value) interface{} {
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
 	}
 
-	return nil, warning
+	return val.Interface()
 }
 
-func newError(msg string) *Error {
-	return &Error{Message: msg}
+// isValidationError indicates whether the err was produced by validation, as opposed
+// to an error returned from a custom Validator.
+func isValidationError(err error) bool {
+	switch err.(type) {
+	case *FieldError, *ValidationErrors:
+		return true
+	default:
+		return false
+	}
 }
 
-func newErrorf(msg string, args ...interface{}) *Error {
-	return &Error{Message: fmt.Sprintf(msg, args...)}
+// prefixPath returns a copy of err with the segment prepended to its path. Errors that
+// were not produced by validation are returned as is.
+func prefixPath(err error, seg PathSegment) error {
+	switch e := err.(type) {
+	case *FieldError:
+		fe := *e
+		fe.Path = append(Path{seg}, e.Path...)
+		return &fe
+	case *ValidationErrors:
+		ve := *e
+		ve.Path = append(Path{seg}, e.Path...)
+		return &ve
+	default:
+		return err
+	}
 }
 
-// Error is a validation error.
-type Error struct {
+// prefixWarning is prefixPath for warnings, which are always prefixed, wrapping those that
+// hold no path, such as plain errors from custom validators.
+func prefixWarning(warning error, seg PathSegment) error {
+	if isValidationError(warning) {
+		return prefixPath(warning, seg)
+	}
+
+	return &ValidationErrors{Path: Path{seg}, Op: "and", Errors: []error{warning}}
+}
+
+// PathSegmentKind is the kind of a PathSegment.
+type PathSegmentKind uint8
+
+// The kinds of path segments.
+const (
+	FieldSegment PathSegmentKind = iota
+	IndexSegment
+	KeySegment
+	// MapKeySegment is a map key itself, as validated by Keys, rather than the value under it.
+	MapKeySegment
+)
+
+// PathSegment is a single step into a value, being either a struct field, a slice or array
+// index, a map value by its key, or a map key itself. Map keys render the same either way.
+type PathSegment struct {
+	Kind  PathSegmentKind
+	Field string
+	Index int
+	Key   interface{}
+}
+
+func fieldSegment(name string) PathSegment {
+	return PathSegment{Kind: FieldSegment, Field: name}
+}
+
+func indexSegment(i int) PathSegment {
+	return PathSegment{Kind: IndexSegment, Index: i}
+}
+
+func keySegment(key reflect.Value) PathSegment {
+	if key.CanInterface() {
+		return PathSegment{Kind: KeySegment, Key: key.Interface()}
+	}
+
+	return PathSegment{Kind: KeySegment, Key: fmt.Sprint(key)}
+}
+
+func mapKeySegment(key reflect.Value) PathSegment {
+	seg := keySegment(key)
+	seg.Kind = MapKeySegment
+	return seg
+}
+
+// String implements the fmt.Stringer interface.
+func (s PathSegment) String() string {
+	switch s.Kind {
+	case IndexSegment:
+		return fmt.Sprintf("[%d]", s.Index)
+	case KeySegment, MapKeySegment:
+		return fmt.Sprintf("[%v]", s.Key)
+	default:
+		return s.Field
+	}
+}
+
+// Path is the location of a value relative to the value being validated.
+type Path []PathSegment
+
+// String renders the path in a dotted form, such as "Address.Lines[0]".
+func (p Path) String() string {
+	var sb strings.Builder
+	for i, seg := range p {
+		if i > 0 && seg.Kind == FieldSegment {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(seg.String())
+	}
+
+	return sb.String()
+}
+
+// prefix renders the path the way it leads an error message.
+func (p Path) prefix() string {
+	var sb strings.Builder
+	for _, seg := range p {
+		switch seg.Kind {
+		case FieldSegment:
+			fmt.Fprintf(&sb, "%q ", seg.Field)
+		default:
+			sb.WriteString(seg.String())
+			sb.WriteByte(' ')
+		}
+	}
+
+	return sb.String()
+}
+
+// FieldError is a single validation failure.
+type FieldError struct {
+	// Path is the location of the failing value.
+	Path Path
+	// ValidatorName is the name of the validator that failed, if known.
+	ValidatorName string
+	// Args are the arguments the validator was configured with.
+	Args []interface{}
+	// Value is the value that failed validation, if it was accessible.
+	Value interface{}
+	// Message describes the failure.
 	Message string
+	// Severity is the severity of the failure. When unspecified, it is SeverityError
+	// for errors and SeverityWarning for warnings.
+	Severity Severity
+
+	// hiddenPath is the number of trailing segments in Path that are not
+	// rendered by Error(), as they were replaced by a custom message.
+	hiddenPath int
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return e.Path[:len(e.Path)-e.hiddenPath].prefix() + e.Message
+}
+
+// Error is a validation error.
+//
+// Deprecated: use FieldError.
+type Error = FieldError
+
+// ValidationErrors is a tree of validation errors joined by a conjunction ("and")
+// or a disjunction ("or").
+type ValidationErrors struct {
+	// Path is the location, shared by all of Errors, of the value being validated.
+	Path Path
+	// Op is either "and" or "or".
+	Op string
+	// Errors holds *FieldError, *ValidationErrors, or errors returned from custom validators.
+	Errors []error
 }
 
 // Error implements the error interface.
-func (e Error) Error() string {
-	return e.Message
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return e.Path.prefix() + strings.Join(msgs, " "+e.Op+" ")
+}
+
+// FieldErrors flattens the tree into its individual failures, each carrying its full path.
+// Errors returned from custom validators are converted into a FieldError holding their message.
+func (e ValidationErrors) FieldErrors() []FieldError {
+	var fes []FieldError
+	for _, err := range e.Errors {
+		switch te := err.(type) {
+		case *FieldError:
+			fe := *te
+			fe.Path = concatPaths(e.Path, te.Path)
+			fes = append(fes, fe)
+		case *ValidationErrors:
+			for _, fe := range te.FieldErrors() {
+				fe.Path = concatPaths(e.Path, fe.Path)
+				fes = append(fes, fe)
+			}
+		default:
+			fes = append(fes, FieldError{Path: concatPaths(e.Path, nil), Message: err.Error()})
+		}
+	}
+
+	return fes
+}
+
+func concatPaths(a, b Path) Path {
+	p := make(Path, 0, len(a)+len(b))
+	p = append(p, a...)
+	return append(p, b...)
 }
 
 // InvalidTagArgumentsError is returned when a tag validator was provided with invalid arguments.
@@ -76,6 +284,98 @@ func (e InvalidTagArgumentsError) Error() string {
 	return "(" + e.ValidatorName + ") " + e.Message
 }
 
+// TagSyntaxError is returned when a struct tag is malformed.
+type TagSyntaxError struct {
+	// Type is the struct type containing the field.
+	Type reflect.Type
+	// Field is the name of the struct field.
+	Field string
+	// TagName is the name of the struct tag, such as "validate".
+	TagName string
+	// Tag is the raw struct tag.
+	Tag string
+	// Offset is the byte offset into Tag where the error was found.
+	Offset int
+	// Expected describes what was expected at Offset.
+	Expected string
+}
+
+// Error implements the error interface.
+func (e TagSyntaxError) Error() string {
+	var location string
+	if e.Type != nil {
+		location = e.Type.String() + "."
+	}
+	if e.Field != "" {
+		location += e.Field + ": "
+	}
+
+	return fmt.Sprintf("%sinvalid %s tag %q at offset %d: expected %s", location, e.TagName, e.Tag, e.Offset, e.Expected)
+}
+
+// ResolutionError is an error resolving the validator for a field of a struct.
+type ResolutionError struct {
+	// Type is the struct type containing the field.
+	Type reflect.Type
+	// Field is the name of the struct field.
+	Field string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e ResolutionError) Error() string {
+	if _, ok := e.Err.(TagSyntaxError); ok {
+		return e.Err.Error()
+	}
+
+	var location string
+	if e.Type != nil {
+		location = e.Type.String() + "."
+	}
+
+	return location + e.Field + ": " + e.Err.Error()
+}
+
+// CompileError is returned when compiling a Registry finds validators that can't be resolved.
+// It holds every error, in the order they were found.
+type CompileError struct {
+	Errors []error
+}
+
+// Error implements the error interface.
+func (e CompileError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	if len(msgs) == 1 {
+		return "error resolving validators: " + msgs[0]
+	}
+
+	return fmt.Sprintf("%d errors resolving validators: %s", len(msgs), strings.Join(msgs, "; "))
+}
+
+// CanceledError is returned when validation stops because its context.Context is done.
+type CanceledError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e CanceledError) Error() string {
+	return "validation canceled: " + e.Err.Error()
+}
+
+// Unwrap returns the context.Context's error.
+func (e CanceledError) Unwrap() error {
+	return e.Err
+}
+
+func isCanceled(err error) bool {
+	_, ok := err.(CanceledError)
+	return ok
+}
+
 // UnknownFieldError is returned a when a field is invalid.
 type UnknownFieldError struct {
 	Type reflect.Type
@@ -112,6 +412,16 @@ func (e ErrNoTagValidatorFactory) Error() string {
 	return "no validator factory found for " + e.Name
 }
 
+// ErrNoEnum is returned when there weren't any valid values available for a type.
+type ErrNoEnum struct {
+	Type reflect.Type
+}
+
+// Error implements the error interface.
+func (e ErrNoEnum) Error() string {
+	return "no enum values found for " + e.Type.String()
+}
+
 // ErrInvalidType is returned when a validator is applied to an unsupported type.
 type ErrInvalidType struct {
 	Type          reflect.Type
@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// fieldMask selects values within the value being validated by their paths. Each path is a
+// list of segments taken from a dotted path such as "Address.City" or "Tags.*", where a
+// segment names a field, an index, or a key, and "*" matches any of them. Brackets are
+// accepted as well, so "Tags[*]" is the same as "Tags.*".
+type fieldMask [][]string
+
+func parseFieldMask(paths []string) fieldMask {
+	if paths == nil {
+		return nil
+	}
+
+	m := make(fieldMask, 0, len(paths))
+	for _, path := range paths {
+		path = strings.Replace(path, "[", ".", -1)
+		path = strings.Replace(path, "]", "", -1)
+		m = append(m, strings.Split(strings.TrimPrefix(path, "."), "."))
+	}
+
+	return m
+}
+
+// reaches indicates whether the path is selected by the mask, or leads to a value that is.
+func (m fieldMask) reaches(p Path) bool {
+	for _, segs := range m {
+		n := len(segs)
+		if len(p) < n {
+			n = len(p)
+		}
+
+		if matchSegments(segs[:n], p[:n]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// covers indicates whether the path is selected by the mask, or is within a value that is.
+func (m fieldMask) covers(p Path) bool {
+	for _, segs := range m {
+		if len(segs) <= len(p) && matchSegments(segs, p[:len(segs)]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSegments(segs []string, p Path) bool {
+	for i, seg := range segs {
+		if seg == "*" {
+			continue
+		}
+
+		switch p[i].Kind {
+		case FieldSegment:
+			if seg != p[i].Field {
+				return false
+			}
+		case IndexSegment:
+			if seg != strconv.Itoa(p[i].Index) {
+				return false
+			}
+		case KeySegment, MapKeySegment:
+			if seg != fmt.Sprint(p[i].Key) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
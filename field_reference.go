@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldReference refers to another field relative to the struct enclosing the value being
+// validated. A reference takes one of the forms:
+//
+//	Field.Sub   a field of the enclosing struct, such as a sibling
+//	.Field.Sub  a field of the struct enclosing the enclosing struct, with one level per dot
+//	$.Field.Sub a field of the outermost struct
+type fieldReference struct {
+	raw   string
+	root  bool
+	up    int
+	names []string
+}
+
+func parseFieldReference(ref string) (fieldReference, error) {
+	fr := fieldReference{raw: ref}
+
+	path := ref
+	if strings.HasPrefix(path, "$.") {
+		fr.root = true
+		path = path[2:]
+	} else {
+		for strings.HasPrefix(path, ".") {
+			fr.up++
+			path = path[1:]
+		}
+	}
+
+	fr.names = strings.Split(path, ".")
+	for _, name := range fr.names {
+		if name == "" {
+			return fr, fmt.Errorf("invalid field reference %q", ref)
+		}
+	}
+
+	return fr, nil
+}
+
+// resolveType finds the type of the referenced field from the structs in the ctx's ancestry. It
+// returns false when the referenced struct isn't known, such as when a type is resolved on its
+// own rather than from within the struct the reference reaches into.
+func (fr fieldReference) resolveType(ctx ResolutionContext) (reflect.Type, bool, error) {
+	var structs []reflect.Type
+	for p := ctx.Parent; p != nil; p = p.Parent {
+		// A struct looked up from the context of a field, or of items, describes the same value.
+		if p.Type.Kind() == reflect.Struct && (p.Parent == nil || p.Parent.Type != p.Type) {
+			structs = append(structs, p.Type)
+		}
+	}
+
+	i, ok := fr.pick(len(structs))
+	if !ok {
+		return nil, false, nil
+	}
+	st := structs[i]
+
+	for _, name := range fr.names {
+		for st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		if st.Kind() != reflect.Struct {
+			return nil, false, UnknownFieldError{Type: st, Name: name}
+		}
+
+		sf, ok := st.FieldByName(name)
+		if !ok {
+			return nil, false, UnknownFieldError{Type: st, Name: name}
+		}
+		st = sf.Type
+	}
+
+	return st, true, nil
+}
+
+// value finds the referenced field from the structs in the ctx's ancestry. An invalid value is
+// returned when a nil pointer is encountered along the way.
+func (fr fieldReference) value(ctx Context) (reflect.Value, error) {
+	var structs []reflect.Value
+	for p := ctx.Parent; p != nil; p = p.Parent {
+		if p.Value.Kind() == reflect.Struct {
+			structs = append(structs, p.Value)
+		}
+	}
+
+	i, ok := fr.pick(len(structs))
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("field reference %q is not within a struct", fr.raw)
+	}
+	val := structs[i]
+
+	for _, name := range fr.names {
+		val = indirect(val)
+		if val.Kind() == reflect.Ptr {
+			return reflect.Value{}, nil
+		}
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, UnknownFieldError{Type: val.Type(), Name: name}
+		}
+
+		f := val.FieldByName(name)
+		if !f.IsValid() {
+			return reflect.Value{}, UnknownFieldError{Type: val.Type(), Name: name}
+		}
+		val = f
+	}
+
+	return val, nil
+}
+
+func (fr fieldReference) pick(numStructs int) (int, bool) {
+	if fr.root {
+		return numStructs - 1, numStructs > 0
+	}
+
+	return fr.up, fr.up < numStructs
+}
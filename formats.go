@@ -0,0 +1,273 @@
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Email requires a string to be an email address, without a display name.
+func Email() Validator {
+	return stringFormat("email", nil, "must be a valid email address", isEmail)
+}
+
+// URL requires a string to be an absolute URL with a host. When schemes are specified,
+// the URL must use one of them.
+func URL(schemes ...string) Validator {
+	msg := "must be a valid URL"
+	switch len(schemes) {
+	case 0:
+	case 1:
+		msg = fmt.Sprintf("must be a valid URL with scheme %s", schemes[0])
+	default:
+		msg = fmt.Sprintf("must be a valid URL with scheme one of %v", schemes)
+	}
+
+	return stringFormat("url", stringsToInterfaces(schemes), msg, func(s string) bool {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return false
+		}
+		if len(schemes) == 0 {
+			return true
+		}
+
+		for _, scheme := range schemes {
+			if strings.EqualFold(u.Scheme, scheme) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// URI requires a string to be an absolute URI.
+func URI() Validator {
+	return stringFormat("uri", nil, "must be a valid URI", func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	})
+}
+
+// Hostname requires a string to be a hostname as described by RFC 1123.
+func Hostname() Validator {
+	return stringFormat("hostname", nil, "must be a valid hostname", isHostname)
+}
+
+// FQDN requires a string to be a fully qualified domain name, optionally ending with a dot.
+func FQDN() Validator {
+	return stringFormat("fqdn", nil, "must be a fully qualified domain name", isFQDN)
+}
+
+// UUID requires a string to be a UUID in its canonical, hyphenated form. When version is not 0,
+// the UUID must be of that version and of the RFC 4122 variant.
+func UUID(version int) Validator {
+	msg := "must be a valid UUID"
+	var args []interface{}
+	if version != 0 {
+		msg = fmt.Sprintf("must be a valid version %d UUID", version)
+		args = []interface{}{version}
+	}
+
+	return stringFormat("uuid", args, msg, func(s string) bool {
+		return isUUID(s, version)
+	})
+}
+
+// ULID requires a string to be a ULID.
+func ULID() Validator {
+	return stringFormat("ulid", nil, "must be a valid ULID", isULID)
+}
+
+// stringFormat requires a string to satisfy valid. A nil pointer fails.
+func stringFormat(name string, args []interface{}, msg string, valid func(string) bool) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		val := indirect(ctx.Value)
+		switch val.Kind() {
+		case reflect.String:
+			if !valid(val.String()) {
+				return newFieldError(ctx, name, args, msg), nil
+			}
+			return nil, nil
+		case reflect.Ptr:
+			return newFieldError(ctx, name, args, msg), nil
+		default:
+			return isStringFormatAllowed(val.Type(), name, nil), nil
+		}
+	})
+}
+
+func isStringFormatAllowed(t reflect.Type, name string, args []string) error {
+	switch t.Kind() {
+	case reflect.String:
+		return nil
+	case reflect.Ptr:
+		return isStringFormatAllowed(t.Elem(), name, args)
+	default:
+		return InvalidTagArgumentsError{Message: "only pointers to/or strings are supported", ValidatorName: name, Args: args}
+	}
+}
+
+func isNoArgStringFormatAllowed(t reflect.Type, name string, args []string) error {
+	if err := isStringFormatAllowed(t, name, args); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		return InvalidTagArgumentsError{Message: "no arguments are allowed", ValidatorName: name, Args: args}
+	}
+
+	return nil
+}
+
+func urlSchemes(t reflect.Type, name string, args []string) ([]string, error) {
+	if err := isStringFormatAllowed(t, name, args); err != nil {
+		return nil, err
+	}
+
+	var schemes []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "scheme=") || len(arg) == len("scheme=") {
+			return nil, InvalidTagArgumentsError{Message: `arguments must be of the form "scheme=<scheme>"`, ValidatorName: name, Args: args}
+		}
+		schemes = append(schemes, strings.TrimPrefix(arg, "scheme="))
+	}
+
+	return schemes, nil
+}
+
+func uuidVersion(t reflect.Type, name string, args []string) (int, error) {
+	if err := isStringFormatAllowed(t, name, args); err != nil {
+		return 0, err
+	}
+
+	switch len(args) {
+	case 0:
+		return 0, nil
+	case 1:
+		version, err := strconv.Atoi(strings.TrimPrefix(args[0], "v"))
+		if err != nil || version < 1 || version > 8 {
+			return 0, InvalidTagArgumentsError{Message: `argument must be a version from "v1" to "v8"`, ValidatorName: name, Args: args}
+		}
+		return version, nil
+	default:
+		return 0, InvalidTagArgumentsError{Message: "at most 1 argument is allowed", ValidatorName: name, Args: args}
+	}
+}
+
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isHostname(s string) bool {
+	if len(s) == 0 || len(s) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(s, ".") {
+		if !isHostnameLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isHostnameLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !isAlphaNumeric(c) && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isFQDN(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if !isHostname(s) {
+		return false
+	}
+
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	// The top-level domain is never entirely numeric, which also rules out IPv4 addresses.
+	_, err := strconv.Atoi(labels[len(labels)-1])
+	return err != nil
+}
+
+func isUUID(s string, version int) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+
+	if version == 0 {
+		return true
+	}
+
+	v, _ := strconv.ParseInt(s[14:15], 16, 8)
+	return int(v) == version && strings.IndexByte("89abAB", s[19]) >= 0
+}
+
+func isULID(s string) bool {
+	if len(s) != 26 || s[0] > '7' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			continue
+		}
+
+		// Crockford's base32 is case insensitive and excludes I, L, O, and U.
+		c |= 0x20
+		if c < 'a' || c > 'z' || c == 'i' || c == 'l' || c == 'o' || c == 'u' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlphaNumeric(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func stringsToInterfaces(ss []string) []interface{} {
+	var is []interface{}
+	for _, s := range ss {
+		is = append(is, s)
+	}
+
+	return is
+}
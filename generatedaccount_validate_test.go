@@ -0,0 +1,207 @@
+// Code generated by validate-gen. DO NOT EDIT.
+
+package validate_test
+
+import (
+	"regexp"
+
+	"github.com/craiggwilson/validate"
+)
+
+var (
+	_generatedAccount_Name_regexp = regexp.MustCompile("^[a-z]+$")
+)
+
+// ValidateGenerated implements validate.GeneratedValidator.
+func (x *generatedAccount) ValidateGenerated(ctx validate.Context) (error, error) {
+	var errs []error
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("Name") {
+		if err := x.validateGenerated_Name(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("Age") {
+		if err := x.validateGenerated_Age(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("Balance") {
+		if err := x.validateGenerated_Balance(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("Role") {
+		if err := x.validateGenerated_Role(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("Tags") {
+		if err := x.validateGenerated_Tags(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("Verified") {
+		if err := x.validateGenerated_Verified(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("Attrs") {
+		if err := x.validateGenerated_Attrs(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if err := ctx.Context().Err(); err != nil {
+		return validate.CanceledError{Err: err}, nil
+	}
+	if ctx.SelectsField("code") {
+		if err := x.validateGenerated_code(ctx); err != nil {
+			errs = append(errs, err)
+			if ctx.Options.StopOnError {
+				return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return &validate.ValidationErrors{Op: "and", Errors: errs}, nil
+	}
+
+	return nil, nil
+}
+
+func (x *generatedAccount) validateGenerated_Name(ctx validate.Context) error {
+	var errs []error
+	if len(x.Name) == 0 {
+		errs = append(errs, &validate.FieldError{ValidatorName: "notempty", Value: x.Name, Message: "must not be empty"})
+		if ctx.Options.StopOnError {
+			return &validate.ValidationErrors{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Name"}}, Op: "and", Errors: errs}
+		}
+	}
+	if len(x.Name) > 8 {
+		errs = append(errs, &validate.FieldError{ValidatorName: "maxlen", Args: []interface{}{8}, Value: x.Name, Message: "must have max length 8"})
+		if ctx.Options.StopOnError {
+			return &validate.ValidationErrors{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Name"}}, Op: "and", Errors: errs}
+		}
+	}
+	if !_generatedAccount_Name_regexp.MatchString(x.Name) {
+		errs = append(errs, &validate.FieldError{ValidatorName: "match", Args: []interface{}{"^[a-z]+$"}, Value: x.Name, Message: "must match \"^[a-z]+$\""})
+		if ctx.Options.StopOnError {
+			return &validate.ValidationErrors{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Name"}}, Op: "and", Errors: errs}
+		}
+	}
+	if len(errs) > 0 {
+		return &validate.ValidationErrors{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Name"}}, Op: "and", Errors: errs}
+	}
+
+	return nil
+}
+
+func (x *generatedAccount) validateGenerated_Age(ctx validate.Context) error {
+	if int64(x.Age) < 18 {
+		return &validate.FieldError{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Age"}}, ValidatorName: "gte", Args: []interface{}{int64(18)}, Value: x.Age, Message: "must be greater than or equal to 18"}
+	}
+
+	return nil
+}
+
+func (x *generatedAccount) validateGenerated_Balance(ctx validate.Context) error {
+	if !(x.Balance < 1000.5) {
+		return &validate.FieldError{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Balance"}}, ValidatorName: "lt", Args: []interface{}{float64(1000.5)}, Value: x.Balance, Message: "must be less than 1000.5"}
+	}
+
+	return nil
+}
+
+func (x *generatedAccount) validateGenerated_Role(ctx validate.Context) error {
+	if x.Role != "admin" && x.Role != "user" {
+		return &validate.FieldError{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Role"}}, ValidatorName: "in", Args: []interface{}{"admin", "user"}, Value: x.Role, Message: "must be one of [admin user]"}
+	}
+
+	return nil
+}
+
+func (x *generatedAccount) validateGenerated_Tags(ctx validate.Context) error {
+	var errs []error
+	if x.Tags == nil {
+		errs = append(errs, &validate.FieldError{ValidatorName: "notnil", Value: x.Tags, Message: "must not be nil"})
+		if ctx.Options.StopOnError {
+			return &validate.ValidationErrors{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Tags"}}, Op: "and", Errors: errs}
+		}
+	}
+	if len(x.Tags) < 1 {
+		errs = append(errs, &validate.FieldError{ValidatorName: "minlen", Args: []interface{}{1}, Value: x.Tags, Message: "must have min length 1"})
+		if ctx.Options.StopOnError {
+			return &validate.ValidationErrors{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Tags"}}, Op: "and", Errors: errs}
+		}
+	}
+	if len(errs) > 0 {
+		return &validate.ValidationErrors{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Tags"}}, Op: "and", Errors: errs}
+	}
+
+	return nil
+}
+
+func (x *generatedAccount) validateGenerated_Verified(ctx validate.Context) error {
+	if x.Verified != true {
+		return &validate.FieldError{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Verified"}}, ValidatorName: "eq", Args: []interface{}{true}, Value: x.Verified, Message: "must be equal to true"}
+	}
+
+	return nil
+}
+
+func (x *generatedAccount) validateGenerated_Attrs(ctx validate.Context) error {
+	if x.Attrs != nil {
+		return &validate.FieldError{Path: validate.Path{{Kind: validate.FieldSegment, Field: "Attrs"}}, ValidatorName: "zero", Value: x.Attrs, Message: "must be \"map[]\""}
+	}
+
+	return nil
+}
+
+func (x *generatedAccount) validateGenerated_code(ctx validate.Context) error {
+	if uint64(x.code) == 0 {
+		return &validate.FieldError{Path: validate.Path{{Kind: validate.FieldSegment, Field: "code"}}, ValidatorName: "neq", Args: []interface{}{uint64(0)}, Message: "must not be equal to 0"}
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package validate
+
+import (
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	tIP           = reflect.TypeOf(net.IP{})
+	tIPNet        = reflect.TypeOf(net.IPNet{})
+	tHardwareAddr = reflect.TypeOf(net.HardwareAddr{})
+)
+
+// IP requires a string or net.IP to be an IPv4 or IPv6 address.
+func IP() Validator {
+	return networkValidator("ip", nil, "must be a valid IP address", isIPAllowed, func(val reflect.Value) bool {
+		return ipOf(val) != nil
+	})
+}
+
+// IPv4 requires a string or net.IP to be an IPv4 address.
+func IPv4() Validator {
+	return networkValidator("ipv4", nil, "must be a valid IPv4 address", isIPAllowed, func(val reflect.Value) bool {
+		if val.Kind() == reflect.String && strings.Contains(val.String(), ":") {
+			return false
+		}
+
+		ip := ipOf(val)
+		return ip != nil && ip.To4() != nil
+	})
+}
+
+// IPv6 requires a string or net.IP to be an IPv6 address.
+func IPv6() Validator {
+	return networkValidator("ipv6", nil, "must be a valid IPv6 address", isIPAllowed, func(val reflect.Value) bool {
+		if val.Kind() == reflect.String && !strings.Contains(val.String(), ":") {
+			return false
+		}
+
+		ip := ipOf(val)
+		return ip != nil && (val.Kind() == reflect.String || ip.To4() == nil)
+	})
+}
+
+// CIDR requires a string or net.IPNet to be an IP network in CIDR notation, such as
+// "192.0.2.0/24".
+func CIDR() Validator {
+	return networkValidator("cidr", nil, "must be a valid CIDR block", isCIDRAllowed, func(val reflect.Value) bool {
+		return ipNetOf(val) != nil
+	})
+}
+
+// CIDRContains requires a string or net.IP address, or a string or net.IPNet network, to be
+// within the block.
+func CIDRContains(block *net.IPNet) Validator {
+	return networkValidator("cidr_contains", []interface{}{block.String()}, "must be within "+block.String(), isCIDRContainsAllowed, func(val reflect.Value) bool {
+		if ipNet := ipNetOf(val); ipNet != nil {
+			ones, _ := ipNet.Mask.Size()
+			outer, _ := block.Mask.Size()
+			return block.Contains(ipNet.IP) && ones >= outer
+		}
+
+		ip := ipOf(val)
+		return ip != nil && block.Contains(ip)
+	})
+}
+
+// HostPort requires a string to be a host, being a hostname or an IP address, and a port, such
+// as "example.com:443" or "[::1]:8080".
+func HostPort() Validator {
+	return networkValidator("hostport", nil, "must be a valid host and port", isHostPortAllowed, func(val reflect.Value) bool {
+		host, port, err := net.SplitHostPort(val.String())
+		if err != nil || !isPort(port) {
+			return false
+		}
+
+		return net.ParseIP(host) != nil || isHostname(host)
+	})
+}
+
+// Port requires a string or an integer to be a port number from 1 to 65535.
+func Port() Validator {
+	return networkValidator("port", nil, "must be a valid port", isPortAllowed, func(val reflect.Value) bool {
+		switch val.Kind() {
+		case reflect.String:
+			return isPort(val.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return val.Int() >= 1 && val.Int() <= 65535
+		default:
+			return val.Uint() >= 1 && val.Uint() <= 65535
+		}
+	})
+}
+
+// MAC requires a string or net.HardwareAddr to be an IEEE 802 MAC-48, EUI-48, EUI-64, or a
+// 20-octet IP over InfiniBand link-layer address.
+func MAC() Validator {
+	return networkValidator("mac", nil, "must be a valid MAC address", isMACAllowed, func(val reflect.Value) bool {
+		if val.Kind() == reflect.String {
+			_, err := net.ParseMAC(val.String())
+			return err == nil
+		}
+
+		switch val.Len() {
+		case 6, 8, 20:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// networkValidator requires a value of a type accepted by allowed to satisfy valid. A nil
+// pointer fails.
+func networkValidator(name string, args []interface{}, msg string, allowed func(reflect.Type, string, []string) error, valid func(reflect.Value) bool) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		val := indirect(ctx.Value)
+		if val.Kind() == reflect.Ptr {
+			return newFieldError(ctx, name, args, msg), nil
+		}
+		if err := allowed(val.Type(), name, nil); err != nil {
+			return err, nil
+		}
+
+		if !valid(val) {
+			return newFieldError(ctx, name, args, msg), nil
+		}
+		return nil, nil
+	})
+}
+
+// ipOf returns the IP address held by a string or net.IP, or nil when it doesn't hold one.
+func ipOf(val reflect.Value) net.IP {
+	if val.Kind() == reflect.String {
+		return net.ParseIP(val.String())
+	}
+	if val.Type() != tIP {
+		return nil
+	}
+
+	ip := net.IP(val.Bytes())
+	if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return nil
+	}
+
+	return ip
+}
+
+// ipNetOf returns the IP network held by a string or net.IPNet, or nil when it doesn't hold one.
+func ipNetOf(val reflect.Value) *net.IPNet {
+	if val.Kind() == reflect.String {
+		_, ipNet, err := net.ParseCIDR(val.String())
+		if err != nil {
+			return nil
+		}
+		return ipNet
+	}
+	if val.Type() != tIPNet {
+		return nil
+	}
+
+	ipNet := &net.IPNet{
+		IP:   net.IP(val.FieldByName("IP").Bytes()),
+		Mask: net.IPMask(val.FieldByName("Mask").Bytes()),
+	}
+	if len(ipNet.IP) != net.IPv4len && len(ipNet.IP) != net.IPv6len {
+		return nil
+	}
+	if _, bits := ipNet.Mask.Size(); bits == 0 {
+		return nil
+	}
+
+	return ipNet
+}
+
+func isPort(s string) bool {
+	port, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && port >= 1
+}
+
+func isNoArgNetworkAllowed(t reflect.Type, name string, args []string, allowed func(reflect.Type, string, []string) error) error {
+	if err := allowed(t, name, args); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		return InvalidTagArgumentsError{Message: "no arguments are allowed", ValidatorName: name, Args: args}
+	}
+
+	return nil
+}
+
+func cidrContainsBlock(t reflect.Type, name string, args []string) (*net.IPNet, error) {
+	if err := isCIDRContainsAllowed(t, name, args); err != nil {
+		return nil, err
+	}
+
+	if len(args) != 1 {
+		return nil, InvalidTagArgumentsError{Message: "1 argument is required", ValidatorName: name, Args: args}
+	}
+
+	_, block, err := net.ParseCIDR(args[0])
+	if err != nil {
+		return nil, InvalidTagArgumentsError{Message: "argument must be a valid CIDR block: " + err.Error(), ValidatorName: name, Args: args}
+	}
+
+	return block, nil
+}
+
+func isIPAllowed(t reflect.Type, name string, args []string) error {
+	return isNetworkAllowed(t, name, args, "only pointers to/or strings and net.IPs are supported", tIP)
+}
+
+func isCIDRAllowed(t reflect.Type, name string, args []string) error {
+	return isNetworkAllowed(t, name, args, "only pointers to/or strings and net.IPNets are supported", tIPNet)
+}
+
+func isCIDRContainsAllowed(t reflect.Type, name string, args []string) error {
+	return isNetworkAllowed(t, name, args, "only pointers to/or strings, net.IPs, and net.IPNets are supported", tIP, tIPNet)
+}
+
+func isHostPortAllowed(t reflect.Type, name string, args []string) error {
+	return isNetworkAllowed(t, name, args, "only pointers to/or strings are supported")
+}
+
+func isMACAllowed(t reflect.Type, name string, args []string) error {
+	return isNetworkAllowed(t, name, args, "only pointers to/or strings and net.HardwareAddrs are supported", tHardwareAddr)
+}
+
+func isPortAllowed(t reflect.Type, name string, args []string) error {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return nil
+	case reflect.Ptr:
+		return isPortAllowed(t.Elem(), name, args)
+	default:
+		return InvalidTagArgumentsError{Message: "only pointers to/or strings and integers are supported", ValidatorName: name, Args: args}
+	}
+}
+
+func isNetworkAllowed(t reflect.Type, name string, args []string, msg string, types ...reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.String {
+		return nil
+	}
+	for _, allowed := range types {
+		if t == allowed {
+			return nil
+		}
+	}
+
+	return InvalidTagArgumentsError{Message: msg, ValidatorName: name, Args: args}
+}
@@ -1,5 +1,7 @@
 package validate
 
+import "time"
+
 var (
 	// DefaultRegistry is the default registry.
 	DefaultRegistry = func() *Registry {
@@ -9,12 +11,15 @@ var (
 	}()
 	// DefaultStopOnError is the default value for stopping validation upon encountering an error.
 	DefaultStopOnError = false
+	// DefaultFailureThreshold is the default severity at which issues fail validation.
+	DefaultFailureThreshold = SeverityError
 )
 
 func defaultOptions() *Options {
 	return &Options{
-		Registry:    DefaultRegistry,
-		StopOnError: DefaultStopOnError,
+		Registry:         DefaultRegistry,
+		StopOnError:      DefaultStopOnError,
+		FailureThreshold: DefaultFailureThreshold,
 	}
 }
 
@@ -23,7 +28,49 @@ type Options struct {
 	Registry         *Registry
 	StopOnError      bool
 	WarningsAsErrors bool
+	FailureThreshold Severity
+	Groups           []string
+	FieldMask        []string
+	ExcludeFields    []string
+	Clock            func() time.Time
 	Validator        Validator
+
+	fieldMask     fieldMask
+	excludeFields fieldMask
+}
+
+// masksFields indicates whether only some of the fields are validated.
+func (o *Options) masksFields() bool {
+	return o.fieldMask != nil || o.excludeFields != nil
+}
+
+// selects indicates whether the value at the path is validated.
+func (o *Options) selects(p Path) bool {
+	if o.fieldMask != nil && !o.fieldMask.reaches(p) {
+		return false
+	}
+
+	return !o.excludeFields.covers(p)
+}
+
+func (o *Options) failureThreshold() Severity {
+	if o.WarningsAsErrors {
+		return SeverityInfo
+	}
+	if o.FailureThreshold == SeverityUnspecified {
+		return SeverityError
+	}
+
+	return o.FailureThreshold
+}
+
+// now returns the current time from the Clock, defaulting to time.Now.
+func (o *Options) now() time.Time {
+	if o == nil || o.Clock == nil {
+		return time.Now()
+	}
+
+	return o.Clock()
 }
 
 func (o *Options) shouldStopOnWarnings() bool {
@@ -33,6 +80,42 @@ func (o *Options) shouldStopOnWarnings() bool {
 // Option provides the ability to alter options.
 type Option func(*Options)
 
+// WithClock indicates the clock used for times relative to now, such as in the future, past,
+// and within tags. It defaults to time.Now.
+func WithClock(clock func() time.Time) Option {
+	return func(opts *Options) {
+		opts.Clock = clock
+	}
+}
+
+// WithExcludeFields indicates that the fields at the dotted paths, and everything within
+// them, are not validated. A "*" in a path matches any field, index, or key.
+func WithExcludeFields(paths []string) Option {
+	return func(opts *Options) {
+		opts.ExcludeFields = append(opts.ExcludeFields, paths...)
+	}
+}
+
+// WithFieldMask indicates that only the fields at the dotted paths, and everything within
+// them, are validated. A "*" in a path matches any field, index, or key. An empty mask
+// validates no fields.
+func WithFieldMask(paths []string) Option {
+	return func(opts *Options) {
+		if opts.FieldMask == nil {
+			opts.FieldMask = []string{}
+		}
+		opts.FieldMask = append(opts.FieldMask, paths...)
+	}
+}
+
+// WithGroups indicates that rules scoped to any of the groups apply, in addition to the rules
+// without groups.
+func WithGroups(groups ...string) Option {
+	return func(opts *Options) {
+		opts.Groups = append(opts.Groups, groups...)
+	}
+}
+
 // WithRegistry makes an option for using a specific Registry.
 func WithRegistry(r *Registry) Option {
 	return func(opts *Options) {
@@ -54,6 +137,13 @@ func WithWarningsAsErrors() Option {
 	}
 }
 
+// WithFailureThreshold indicates the severity at which issues fail validation.
+func WithFailureThreshold(s Severity) Option {
+	return func(opts *Options) {
+		opts.FailureThreshold = s
+	}
+}
+
 // WithValidator indicates the validator to use, skipping the registry.
 func WithValidator(validator Validator) Option {
 	return func(opts *Options) {
@@ -1,7 +1,10 @@
 package validate
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -12,6 +15,7 @@ func NewRegistryBuilder() *RegistryBuilder {
 		structTagParser:       DefaultStructTagParser,
 		validators:            make(map[reflect.Type]Validator),
 		tagValidatorFactories: make(map[string]TagValidatorFactory),
+		enums:                 make(map[reflect.Type][]interface{}),
 	}
 }
 
@@ -21,6 +25,9 @@ type RegistryBuilder struct {
 	structTagParser       StructTagParser
 	validators            map[reflect.Type]Validator
 	tagValidatorFactories map[string]TagValidatorFactory
+	enums                 map[reflect.Type][]interface{}
+	preload               []reflect.Type
+	generated             bool
 }
 
 // Build the registry.
@@ -28,27 +35,72 @@ func (rb *RegistryBuilder) Build() *Registry {
 	r := Registry{
 		structTagName:         rb.structTagName,
 		structTagParser:       rb.structTagParser,
-		validators:            make(map[reflect.Type]Validator),
+		registered:            make(map[reflect.Type]Validator),
 		tagValidatorFactories: make(map[string]TagValidatorFactory),
+		enums:                 make(map[reflect.Type][]interface{}),
+		preload:               append([]reflect.Type(nil), rb.preload...),
+		generated:             rb.generated,
+		inflight:              make(map[validatorKey]*resolution),
 	}
-
 	for t, v := range rb.validators {
-		r.validators[t] = v
+		r.registered[t] = v
 	}
 
 	for t, vf := range rb.tagValidatorFactories {
 		r.tagValidatorFactories[t] = vf
 	}
 
+	for t, values := range rb.enums {
+		r.enums[t] = values
+	}
+
 	return &r
 }
 
+// Preload adds types whose validators are resolved when the Registry is compiled with Compile
+// or MustCompile, along with the validators of the types passed to those.
+func (rb *RegistryBuilder) Preload(types ...reflect.Type) *RegistryBuilder {
+	rb.preload = append(rb.preload, types...)
+	return rb
+}
+
+// UseGeneratedValidators validates structs implementing GeneratedValidator with their generated
+// code rather than by reflecting over their fields. The code is generated from the "validate"
+// struct tags using the builtin validators, so this should only be used when the struct tag name
+// and the factories registered under those validators' names are the defaults.
+func (rb *RegistryBuilder) UseGeneratedValidators() *RegistryBuilder {
+	rb.generated = true
+	return rb
+}
+
 // RegisterValidator registers a Validator for the specific type.
 func (rb *RegistryBuilder) RegisterValidator(t reflect.Type, v Validator) *RegistryBuilder {
 	rb.validators[t] = v
 	return rb
 }
 
+// RegisterEnum registers the valid values of the specific type for the enum tag. Values of
+// another type are converted to it, and it panics if one is nil or can't be converted.
+func (rb *RegistryBuilder) RegisterEnum(t reflect.Type, values ...interface{}) *RegistryBuilder {
+	var enum []interface{}
+	for _, v := range values {
+		val := reflect.ValueOf(v)
+		if !val.IsValid() {
+			panic(fmt.Sprintf("validate: nil enum value for %s", t))
+		}
+		if val.Type() != t {
+			if !val.Type().ConvertibleTo(t) {
+				panic(fmt.Sprintf("validate: enum value %#v of type %s cannot be converted to %s", v, val.Type(), t))
+			}
+			val = val.Convert(t)
+		}
+		enum = append(enum, val.Interface())
+	}
+
+	rb.enums[t] = enum
+	return rb
+}
+
 // RegisterTagValidatorFactory registers a TagValidatorFactory for the specified name.
 func (rb *RegistryBuilder) RegisterTagValidatorFactory(name string, vf TagValidatorFactory) *RegistryBuilder {
 	rb.tagValidatorFactories[name] = vf
@@ -71,12 +123,105 @@ func (rb *RegistryBuilder) SetStructTagParser(stp StructTagParser) *RegistryBuil
 type Registry struct {
 	structTagName         string
 	structTagParser       StructTagParser
-	validators            map[reflect.Type]Validator
+	registered            map[reflect.Type]Validator
 	tagValidatorFactories map[string]TagValidatorFactory
+	enums                 map[reflect.Type][]interface{}
+	preload               []reflect.Type
+	generated             bool
+
+	// validators maps each validatorKey to the resolved validator.
+	validators sync.Map
+	// lock guards inflight and the resolutions' waiting on each other.
+	lock     sync.Mutex
+	inflight map[validatorKey]*resolution
+}
+
+// resolved is the outcome of resolving a validator. Failures are cached as well, so that
+// types without validators aren't resolved again on every lookup.
+type resolved struct {
+	validator Validator
+	err       error
+}
+
+// resolution is a validator being resolved, which concurrent lookups of it wait for rather than
+// resolving it again.
+type resolution struct {
+	done  chan struct{}
+	owner *resolver
+	resolved
+}
 
-	lock sync.RWMutex
+// resolver is a lookup resolving validators, including those looked up while resolving them.
+type resolver struct {
+	// waiting is the resolution the lookup is waiting for, if any.
+	waiting *resolution
 }
 
+// waitsFor indicates whether waiting for the resolution would have the resolver wait for itself,
+// as the resolution is its own or one waiting for it.
+func (rv *resolver) waitsFor(f *resolution) bool {
+	for ; f != nil; f = f.owner.waiting {
+		if f.owner == rv {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validatorKey identifies a resolved validator, which differs by the validation groups it was
+// resolved for.
+type validatorKey struct {
+	t      reflect.Type
+	groups string
+}
+
+// Compile resolves the validators for the types and those preloaded by the RegistryBuilder,
+// along with the types reachable from their struct tags, so that invalid struct tags are found
+// at startup rather than when a type is first validated. The rules of every validation group
+// are checked, and rather than stopping at the first, every error is reported in a CompileError.
+func (r *Registry) Compile(types ...reflect.Type) error {
+	c := &compilation{failed: make(map[validatorKey]bool)}
+	for _, t := range append(append([]reflect.Type(nil), r.preload...), types...) {
+		ctx := ResolutionContext{
+			structTagParser: r.structTagParser,
+			Type:            t,
+			registry:        r,
+			compilation:     c,
+		}
+
+		if _, err := r.lookupValidator(ctx); err != nil && err != errReported {
+			c.errs = append(c.errs, err)
+		}
+	}
+
+	if len(c.errs) > 0 {
+		return CompileError{Errors: c.errs}
+	}
+
+	return nil
+}
+
+// MustCompile is like Compile, but panics with the CompileError. It returns the Registry so
+// that it can initialize a variable.
+func (r *Registry) MustCompile(types ...reflect.Type) *Registry {
+	if err := r.Compile(types...); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// compilation collects the errors of resolving validators during Compile.
+type compilation struct {
+	errs []error
+	// failed holds the validators that couldn't be resolved, whose errors were reported.
+	failed map[validatorKey]bool
+}
+
+// errReported is returned in place of an error that was collected by a compilation.
+var errReported = errors.New("validate: the error was reported")
+
 // LookupTagValidatorFactory will inspect the registry for a ValidatorFactory
 // of the specified name.
 func (r *Registry) LookupTagValidatorFactory(name string) (TagValidatorFactory, error) {
@@ -88,25 +233,42 @@ func (r *Registry) LookupTagValidatorFactory(name string) (TagValidatorFactory,
 	return vf, nil
 }
 
+// LookupEnum will inspect the registry for the valid values of the type, falling back to those
+// returned by a Values method of the type. If there are none, an error will be returned.
+func (r *Registry) LookupEnum(t reflect.Type) ([]interface{}, error) {
+	if values, ok := r.enums[t]; ok {
+		return values, nil
+	}
+
+	if values, ok := valuesOf(t); ok {
+		return values, nil
+	}
+
+	return nil, ErrNoEnum{Type: t}
+}
+
 // LookupValidator will inspect the registry for a Validator for
 // the type provided. If no validator is found, an error will be returned.
 func (r *Registry) LookupValidator(t reflect.Type) (Validator, error) {
+	return r.lookupValidatorInGroups(t, nil)
+}
+
+func (r *Registry) lookupValidatorInGroups(t reflect.Type, groups []string) (Validator, error) {
 	ctx := ResolutionContext{
 		structTagParser: r.structTagParser,
 		Type:            t,
 		registry:        r,
+		groups:          normalizeGroups(groups),
 	}
 
 	return r.lookupValidator(ctx)
 }
 
 var tValidator = reflect.TypeOf((*Validator)(nil)).Elem()
+var tGeneratedValidator = reflect.TypeOf((*GeneratedValidator)(nil)).Elem()
 
 func (r *Registry) lookupValidator(ctx ResolutionContext) (Validator, error) {
-	r.lock.RLock()
-	v, ok := r.validators[ctx.Type]
-	r.lock.RUnlock()
-	if ok {
+	if v, ok := r.registered[ctx.Type]; ok {
 		if v == nil {
 			return nil, ErrNoValidator{ctx.Type}
 		}
@@ -114,14 +276,98 @@ func (r *Registry) lookupValidator(ctx ResolutionContext) (Validator, error) {
 		return v, nil
 	}
 
-	var err error
-	v, err = buildValidator(ctx)
-	if err != nil {
-		return nil, err
+	key := validatorKey{t: ctx.Type, groups: strings.Join(ctx.groups, ",")}
+
+	// Compiling reports the errors of every field, so errors cached by an earlier lookup are
+	// resolved again rather than reported as is.
+	if res, ok := r.cached(key); ok && (res.err == nil || ctx.compilation == nil) {
+		return res.validator, res.err
+	}
+
+	if ctx.compilation != nil {
+		if ctx.compilation.failed[key] {
+			return nil, errReported
+		}
+
+		v, err := buildValidator(ctx)
+		if err != nil {
+			// The error may have been reported in place of the actual one, so it isn't cached.
+			ctx.compilation.failed[key] = true
+			return nil, err
+		}
+
+		res := r.cache(key, resolved{validator: v})
+		return res.validator, res.err
+	}
+
+	if ctx.resolver == nil {
+		ctx.resolver = &resolver{}
 	}
 
 	r.lock.Lock()
-	r.validators[ctx.Type] = v
+	if res, ok := r.cached(key); ok {
+		r.lock.Unlock()
+		return res.validator, res.err
+	}
+
+	// Lookups wait for a resolution in flight unless it's waiting for them, in which case they
+	// resolve the validator themselves instead.
+	f, inflight := r.inflight[key]
+	if inflight && !ctx.resolver.waitsFor(f) {
+		ctx.resolver.waiting = f
+		r.lock.Unlock()
+		<-f.done
+
+		r.lock.Lock()
+		ctx.resolver.waiting = nil
+		r.lock.Unlock()
+		return f.validator, f.err
+	}
+	if !inflight {
+		f = &resolution{
+			done:     make(chan struct{}),
+			owner:    ctx.resolver,
+			resolved: resolved{err: fmt.Errorf("resolving the validator for %s panicked", ctx.Type)},
+		}
+		r.inflight[key] = f
+	}
 	r.lock.Unlock()
-	return v, nil
+
+	if inflight {
+		return r.resolve(ctx, key)
+	}
+
+	defer func() {
+		r.lock.Lock()
+		delete(r.inflight, key)
+		r.lock.Unlock()
+		close(f.done)
+	}()
+
+	f.validator, f.err = r.resolve(ctx, key)
+	return f.validator, f.err
+}
+
+// resolve builds the validator and caches the outcome, unless another lookup did so first.
+func (r *Registry) resolve(ctx ResolutionContext, key validatorKey) (Validator, error) {
+	v, err := buildValidator(ctx)
+	res := r.cache(key, resolved{validator: v, err: err})
+	return res.validator, res.err
+}
+
+// cached returns the outcome of resolving the validator, if it has been.
+func (r *Registry) cached(key validatorKey) (resolved, bool) {
+	res, ok := r.validators.Load(key)
+	if !ok {
+		return resolved{}, false
+	}
+
+	return res.(resolved), true
+}
+
+// cache caches the outcome of resolving a validator, unless another lookup did so first, and
+// returns the one cached.
+func (r *Registry) cache(key validatorKey, res resolved) resolved {
+	actual, _ := r.validators.LoadOrStore(key, res)
+	return actual.(resolved)
 }
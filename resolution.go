@@ -3,6 +3,8 @@ package validate
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"sync/atomic"
 )
 
 // ResolutionContext holds contextual information for resolving a validator.
@@ -13,6 +15,71 @@ type ResolutionContext struct {
 
 	registry        *Registry
 	structTagParser StructTagParser
+	groups          []string
+	compilation     *compilation
+	resolver        *resolver
+}
+
+// Groups returns the validation groups the validator is being resolved for.
+func (ctx *ResolutionContext) Groups() []string {
+	return ctx.groups
+}
+
+// inGroups indicates whether rules scoped to the groups apply. Rules without groups always apply.
+func (ctx *ResolutionContext) inGroups(groups []string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+
+	for _, g := range groups {
+		i := sort.SearchStrings(ctx.groups, g)
+		if i < len(ctx.groups) && ctx.groups[i] == g {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeGroups sorts and removes duplicates from the groups.
+func normalizeGroups(groups []string) []string {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	sorted := append([]string(nil), groups...)
+	sort.Strings(sorted)
+
+	normalized := sorted[:1]
+	for _, g := range sorted[1:] {
+		if g != normalized[len(normalized)-1] {
+			normalized = append(normalized, g)
+		}
+	}
+
+	return normalized
+}
+
+// collect adds the err to the errors of the compilation, if any, indicating whether resolution
+// may continue past it.
+func (ctx *ResolutionContext) collect(err error) bool {
+	if ctx.compilation == nil {
+		return false
+	}
+	if err == errReported {
+		return true
+	}
+
+	re := ResolutionError{Field: ctx.StructField.Name, Err: err}
+	for p := ctx.Parent; p != nil; p = p.Parent {
+		if p.Type.Kind() == reflect.Struct {
+			re.Type = p.Type
+			break
+		}
+	}
+
+	ctx.compilation.errs = append(ctx.compilation.errs, re)
+	return true
 }
 
 // LookupTagValidatorFactory will inspect the registry for a ValidatorFactory
@@ -26,7 +93,7 @@ func (ctx *ResolutionContext) LookupValidator(t reflect.Type) (Validator, error)
 	parent := ctx.Parent
 	for parent != nil {
 		if parent.Type == t {
-			return delayedLookup(ctx.registry, t), nil
+			return delayedLookup(ctx.registry, t, ctx.groups), nil
 		}
 		parent = parent.Parent
 	}
@@ -42,6 +109,101 @@ func (ctx *ResolutionContext) ParseStructTags(tagName string) (*StructTagParseRe
 	return ctx.structTagParser.ParseStructTags(*ctx, tagName)
 }
 
+// StructTagSchema applies the rules in the struct tags for the given tag name to the
+// JSON Schema. Only the TagValidatorFactories implementing SchemaContributor contribute,
+// disjunctions are described with "anyOf", and a custom message becomes the "description".
+func (ctx *ResolutionContext) StructTagSchema(tagName string, s *Schema) error {
+	tag, ok := ctx.StructField.Tag.Lookup(tagName)
+	if !ok || tag == "-" {
+		return nil
+	}
+
+	st, err := parseStructTagFor(*ctx, tagName, tag)
+	if err != nil {
+		return err
+	}
+
+	if st.customMessage != "" {
+		s.Description = st.customMessage
+	}
+
+	for _, section := range st.sections {
+		if !ctx.inGroups(section.groups) {
+			continue
+		}
+
+		if err = contributeDisjunctionSchema(*ctx, section.disjunctions, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func contributeDisjunctionSchema(ctx ResolutionContext, disjunctions [][]tagValidator, s *Schema) error {
+	switch len(disjunctions) {
+	case 0:
+		return nil
+	case 1:
+		return contributeConjunctionSchema(ctx, disjunctions[0], s)
+	}
+
+	required := true
+	var anyOf []*Schema
+	for _, conjunction := range disjunctions {
+		fragment := &Schema{}
+		if err := contributeConjunctionSchema(ctx, conjunction, fragment); err != nil {
+			return err
+		}
+
+		required = required && fragment.IsRequired
+		anyOf = append(anyOf, fragment)
+	}
+	s.IsRequired = s.IsRequired || required
+
+	if s.AnyOf == nil {
+		s.AnyOf = anyOf
+	} else {
+		s.AllOf = append(s.AllOf, &Schema{AnyOf: anyOf})
+	}
+
+	return nil
+}
+
+func contributeConjunctionSchema(ctx ResolutionContext, conjunction []tagValidator, s *Schema) error {
+	for _, tv := range conjunction {
+		if tv.name == diveKeyword {
+			ictx, err := diveResolutionContext(ctx, tv)
+			if err != nil {
+				return err
+			}
+
+			return contributeDisjunctionSchema(ictx, tv.items, itemsSchemaOf(ctx.Type, s))
+		}
+
+		vf, err := ctx.LookupTagValidatorFactory(tv.name)
+		if err != nil {
+			return err
+		}
+
+		// Rules that only inform or warn aren't enforced, and so aren't described.
+		if tv.severity == SeverityInfo || tv.severity == SeverityWarning {
+			continue
+		}
+
+		sc, ok := vf.(SchemaContributor)
+		if !ok {
+			continue
+		}
+
+		if err = sc.ContributeSchema(ctx, tv.name, tv.args, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func buildValidator(ctx ResolutionContext) (Validator, error) {
 	// We want to consider only the base type T. If we get *T, then avoid adding
 	// its Validator() implementation for now, and pass it to
@@ -78,7 +240,14 @@ func buildValidator(ctx ResolutionContext) (Validator, error) {
 func buildNonImplValidator(ctx ResolutionContext) (Validator, error) {
 	switch ctx.Type.Kind() {
 	case reflect.Struct:
-		return buildStructValidator(ctx)
+		v, err := buildStructValidator(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.registry.generated && reflect.PtrTo(ctx.Type).Implements(tGeneratedValidator) {
+			return generatedValidator(v), nil
+		}
+		return v, nil
 	case reflect.Ptr:
 		// If we got *T, get T and have it hit buildValidator() eventually.
 		v, err := ctx.LookupValidator(ctx.Type.Elem())
@@ -94,9 +263,10 @@ func buildNonImplValidator(ctx ResolutionContext) (Validator, error) {
 		}), nil
 	case reflect.Interface:
 		registry := ctx.registry
+		groups := ctx.groups
 		return ValidatorFunc(func(ctx Context) (error, error) {
 			val := ctx.Value.Elem()
-			v, err := registry.LookupValidator(val.Type())
+			v, err := registry.lookupValidatorInGroups(val.Type(), groups)
 			if err != nil {
 				return err, nil
 			}
@@ -112,6 +282,30 @@ func buildNonImplValidator(ctx ResolutionContext) (Validator, error) {
 	return nil, ErrNoValidator{Type: ctx.Type}
 }
 
+// generatedValidator validates with the GeneratedValidator implementation of the value. The
+// fallback, built from the struct tags, is used when the value was reached through unexported
+// fields and so cannot be handed to the generated code.
+func generatedValidator(fallback Validator) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		if !ctx.Value.IsValid() {
+			return nil, nil
+		}
+		if !ctx.Value.CanInterface() {
+			return fallback.Validate(ctx)
+		}
+
+		var ptr reflect.Value
+		if ctx.Value.CanAddr() {
+			ptr = ctx.Value.Addr()
+		} else {
+			ptr = reflect.New(ctx.Value.Type())
+			ptr.Elem().Set(ctx.Value)
+		}
+
+		return ptr.Interface().(GeneratedValidator).ValidateGenerated(ctx)
+	})
+}
+
 // Struct builds a validator based on struct tags for the type.
 func buildStructValidator(ctx ResolutionContext) (Validator, error) {
 	if ctx.Type.Kind() != reflect.Struct {
@@ -119,6 +313,7 @@ func buildStructValidator(ctx ResolutionContext) (Validator, error) {
 	}
 	numFields := ctx.Type.NumField()
 	var validators []Validator
+	var failed bool
 	for i := 0; i < numFields; i++ {
 		sf := ctx.Type.Field(i)
 
@@ -129,7 +324,11 @@ func buildStructValidator(ctx ResolutionContext) (Validator, error) {
 
 		stpr, err := cctx.ParseStructTags(cctx.registry.structTagName)
 		if err != nil {
-			return nil, err
+			if !cctx.collect(err) {
+				return nil, err
+			}
+			failed = true
+			continue
 		}
 
 		validator := stpr.Validator
@@ -142,20 +341,35 @@ func buildStructValidator(ctx ResolutionContext) (Validator, error) {
 		validators = append(validators, validator)
 	}
 
+	if failed {
+		return nil, errReported
+	}
+
 	return And(validators...), nil
 }
 
-func delayedLookup(r *Registry, t reflect.Type) Validator {
-	var v Validator
+// delayedLookup looks up the validator of a recursive type when it's first needed, since it's
+// still being resolved when the lookup is made. The validator is safe to use concurrently.
+func delayedLookup(r *Registry, t reflect.Type, groups []string) Validator {
+	var resolved atomic.Value
 	return ValidatorFunc(func(ctx Context) (error, error) {
-		if v == nil {
-			var err error
-			v, err = r.LookupValidator(t)
+		v, ok := resolved.Load().(delayedValidator)
+		if !ok {
+			validator, err := r.lookupValidatorInGroups(t, groups)
 			if err != nil {
 				return err, nil
 			}
+
+			v = delayedValidator{validator}
+			resolved.Store(v)
 		}
 
 		return v.Validate(ctx)
 	})
 }
+
+// delayedValidator holds a validator looked up by delayedLookup, since an atomic.Value always
+// holds the same concrete type.
+type delayedValidator struct {
+	Validator
+}
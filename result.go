@@ -0,0 +1,231 @@
+package validate
+
+// Severity is the severity of an issue found during validation.
+type Severity uint8
+
+// The severities, in increasing order. An unspecified severity is determined by whether a
+// Validator returned the issue as an error or as a warning.
+const (
+	SeverityUnspecified Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityFatal
+)
+
+// String implements the fmt.Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return "unspecified"
+	}
+}
+
+func parseSeverity(s string) (Severity, bool) {
+	switch s {
+	case "info":
+		return SeverityInfo, true
+	case "warn", "warning":
+		return SeverityWarning, true
+	case "error":
+		return SeverityError, true
+	case "fatal":
+		return SeverityFatal, true
+	default:
+		return SeverityUnspecified, false
+	}
+}
+
+// Result holds the outcome of validation.
+type Result struct {
+	// Issues holds every issue found, each with its severity specified.
+	Issues []FieldError
+
+	threshold Severity
+	err       error
+	warning   error
+}
+
+func newResult(err error, warning error, threshold Severity) *Result {
+	r := &Result{threshold: threshold, err: err, warning: warning}
+	r.Issues = append(r.Issues, issues(err, SeverityError)...)
+	r.Issues = append(r.Issues, issues(warning, SeverityWarning)...)
+	return r
+}
+
+func issues(err error, def Severity) []FieldError {
+	var fes []FieldError
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *FieldError:
+		fes = []FieldError{*e}
+	case *ValidationErrors:
+		fes = e.FieldErrors()
+	default:
+		fes = []FieldError{{Message: err.Error()}}
+	}
+
+	for i := range fes {
+		if fes[i].Severity == SeverityUnspecified {
+			fes[i].Severity = def
+		}
+	}
+
+	return fes
+}
+
+// Failed indicates whether any issue is at or above the failure threshold.
+func (r *Result) Failed() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity >= r.threshold {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns the issues at or above the failure threshold as an error.
+func (r *Result) Err() error {
+	failing := func(s Severity) bool { return s >= r.threshold }
+	return mergeIssues(
+		filterSeverity(r.err, SeverityError, failing),
+		filterSeverity(r.warning, SeverityWarning, failing),
+	)
+}
+
+// Warning returns the issues below the failure threshold as an error.
+func (r *Result) Warning() error {
+	passing := func(s Severity) bool { return s < r.threshold }
+	return mergeIssues(
+		filterSeverity(r.err, SeverityError, passing),
+		filterSeverity(r.warning, SeverityWarning, passing),
+	)
+}
+
+func mergeIssues(err error, warning error) error {
+	switch {
+	case err == nil:
+		return warning
+	case warning == nil:
+		return err
+	default:
+		return mergeWarning(err, warning)
+	}
+}
+
+// filterSeverity returns the parts of err whose severities satisfy keep, treating unspecified
+// severities as def. The err is returned as is when nothing is removed.
+func filterSeverity(err error, def Severity, keep func(Severity) bool) error {
+	f, _ := filterSeverityChanged(err, def, keep)
+	return f
+}
+
+func filterSeverityChanged(err error, def Severity, keep func(Severity) bool) (error, bool) {
+	switch e := err.(type) {
+	case nil:
+		return nil, false
+	case *FieldError:
+		s := e.Severity
+		if s == SeverityUnspecified {
+			s = def
+		}
+		if keep(s) {
+			return e, false
+		}
+		return nil, true
+	case *ValidationErrors:
+		var errs []error
+		changed := false
+		for _, child := range e.Errors {
+			f, c := filterSeverityChanged(child, def, keep)
+			changed = changed || c
+			if f != nil {
+				errs = append(errs, f)
+			}
+		}
+
+		if !changed {
+			return e, false
+		}
+		if len(errs) == 0 {
+			return nil, true
+		}
+
+		ve := *e
+		ve.Errors = errs
+		return &ve, true
+	default:
+		if keep(def) {
+			return err, false
+		}
+		return nil, true
+	}
+}
+
+// hasSeverity indicates whether any issue in err has the severity.
+func hasSeverity(err error, s Severity) bool {
+	switch e := err.(type) {
+	case *FieldError:
+		return e.Severity == s
+	case *ValidationErrors:
+		for _, child := range e.Errors {
+			if hasSeverity(child, s) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// withSeverity returns a copy of err with every issue set to the severity. Errors from
+// custom validators become a FieldError holding their message.
+func withSeverity(err error, s Severity) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *FieldError:
+		fe := *e
+		fe.Severity = s
+		return &fe
+	case *ValidationErrors:
+		ve := *e
+		ve.Errors = make([]error, len(e.Errors))
+		for i, child := range e.Errors {
+			ve.Errors[i] = withSeverity(child, s)
+		}
+		return &ve
+	default:
+		return &FieldError{Message: err.Error(), Severity: s}
+	}
+}
+
+// AtSeverity reports the issues found by the validator with the severity. Info and warning
+// issues are returned as warnings, while error and fatal issues are returned as errors. A
+// fatal issue stops validation.
+func AtSeverity(validator Validator, s Severity) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		err, warning := validator.Validate(ctx)
+		if isCanceled(err) {
+			return err, nil
+		}
+
+		err = withSeverity(err, s)
+		warning = withSeverity(warning, s)
+		if s >= SeverityError {
+			return mergeWarning(err, warning), nil
+		}
+
+		return nil, mergeWarning(err, warning)
+	})
+}
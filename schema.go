@@ -0,0 +1,654 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect produced by Registry.JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document, or a fragment of one.
+type Schema struct {
+	Schema string             `json:"$schema,omitempty"`
+	Ref    string             `json:"$ref,omitempty"`
+	Defs   map[string]*Schema `json:"$defs,omitempty"`
+
+	Type            string        `json:"type,omitempty"`
+	Description     string        `json:"description,omitempty"`
+	ContentEncoding string        `json:"contentEncoding,omitempty"`
+	Const           interface{}   `json:"const,omitempty"`
+	Enum            []interface{} `json:"enum,omitempty"`
+	Not             *Schema       `json:"not,omitempty"`
+	AllOf           []*Schema     `json:"allOf,omitempty"`
+	AnyOf           []*Schema     `json:"anyOf,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	UniqueItems bool `json:"uniqueItems,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
+
+	Minimum          interface{} `json:"minimum,omitempty"`
+	Maximum          interface{} `json:"maximum,omitempty"`
+	ExclusiveMinimum interface{} `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum interface{} `json:"exclusiveMaximum,omitempty"`
+
+	// IsRequired indicates the value must be present in its enclosing object,
+	// which lists it in its Required properties.
+	IsRequired bool `json:"-"`
+}
+
+// SchemaContributor is an optional interface for a TagValidatorFactory, allowing it to
+// describe the validators it creates as a JSON Schema fragment.
+type SchemaContributor interface {
+	// ContributeSchema adds the keywords for the named validator and its arguments to the
+	// schema, which may already describe ctx.Type.
+	ContributeSchema(ctx ResolutionContext, name string, args []string, s *Schema) error
+}
+
+// JSONSchema produces a JSON Schema document describing t along with the rules from its
+// struct tags. Properties are named after the fields' json tags, and named struct types are
+// placed in "$defs", which allows for recursive types.
+func (r *Registry) JSONSchema(t reflect.Type) (*Schema, error) {
+	if _, err := r.LookupValidator(t); err != nil {
+		if _, ok := err.(ErrNoValidator); !ok {
+			return nil, err
+		}
+	}
+
+	b := newSchemaBuilder(r, "#/$defs/", jsonPropertyName)
+	s, err := b.typeSchema(ResolutionContext{
+		structTagParser: r.structTagParser,
+		Type:            t,
+		registry:        r,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.Schema = JSONSchemaDialect
+	if len(b.defs) > 0 {
+		s.Defs = b.defs
+	}
+
+	return s, nil
+}
+
+// OpenAPIComponents is the "components" section of an OpenAPI 3.1 document.
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// OpenAPIComponents produces the "components" section of an OpenAPI 3.1 document describing
+// the named types along with the rules from their struct tags. Properties are named after
+// the fields' json tags, and every named struct type reachable from the types is included.
+func (r *Registry) OpenAPIComponents(types ...reflect.Type) (*OpenAPIComponents, error) {
+	b := newSchemaBuilder(r, "#/components/schemas/", jsonPropertyName)
+	for _, t := range types {
+		if _, err := r.LookupValidator(t); err != nil {
+			if _, ok := err.(ErrNoValidator); !ok {
+				return nil, err
+			}
+		}
+
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Name() == "" {
+			return nil, fmt.Errorf("cannot produce a component schema for unnamed type %s", t)
+		}
+
+		s, err := b.typeSchema(ResolutionContext{
+			structTagParser: r.structTagParser,
+			Type:            t,
+			registry:        r,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if s.Ref == "" {
+			b.defs[t.Name()] = s
+		}
+	}
+
+	return &OpenAPIComponents{Schemas: b.defs}, nil
+}
+
+func jsonPropertyName(sf reflect.StructField) (string, bool) {
+	if sf.PkgPath != "" {
+		return "", false
+	}
+
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+
+	return sf.Name, true
+}
+
+func newSchemaBuilder(r *Registry, refPrefix string, propertyName func(reflect.StructField) (string, bool)) *schemaBuilder {
+	return &schemaBuilder{
+		registry:     r,
+		refPrefix:    refPrefix,
+		propertyName: propertyName,
+		defs:         make(map[string]*Schema),
+		refs:         make(map[reflect.Type]string),
+	}
+}
+
+type schemaBuilder struct {
+	registry     *Registry
+	refPrefix    string
+	propertyName func(reflect.StructField) (string, bool)
+
+	defs map[string]*Schema
+	refs map[reflect.Type]string
+}
+
+func (b *schemaBuilder) typeSchema(ctx ResolutionContext) (*Schema, error) {
+	t := ctx.Type
+	switch t {
+	case tTime:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case tDuration:
+		// encoding/json writes durations as their number of nanoseconds.
+		return &Schema{Type: "integer", Description: "duration in nanoseconds"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.typeSchema(childResolutionContext(ctx, t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", ContentEncoding: "base64"}, nil
+		}
+
+		items, err := b.typeSchema(childResolutionContext(ctx, t.Elem()))
+		if err != nil {
+			return nil, err
+		}
+
+		s := &Schema{Type: "array", Items: items}
+		if t.Kind() == reflect.Array {
+			n := t.Len()
+			s.MinItems = &n
+			s.MaxItems = &n
+		}
+		return s, nil
+	case reflect.Map:
+		values, err := b.typeSchema(childResolutionContext(ctx, t.Elem()))
+		if err != nil {
+			return nil, err
+		}
+
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(ctx)
+		}
+
+		return b.refSchema(ctx)
+	default:
+		return &Schema{}, nil
+	}
+}
+
+func (b *schemaBuilder) refSchema(ctx ResolutionContext) (*Schema, error) {
+	name, ok := b.refs[ctx.Type]
+	if !ok {
+		name = ctx.Type.Name()
+		for i := 2; b.defs[name] != nil; i++ {
+			name = ctx.Type.Name() + strconv.Itoa(i)
+		}
+
+		// Reserve the name before building so that recursive types refer back to it.
+		b.refs[ctx.Type] = name
+		b.defs[name] = &Schema{}
+
+		s, err := b.structSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.defs[name] = s
+	}
+
+	return &Schema{Ref: b.refPrefix + name}, nil
+}
+
+func (b *schemaBuilder) structSchema(ctx ResolutionContext) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	var promoted []*Schema
+	for i := 0; i < ctx.Type.NumField(); i++ {
+		sf := ctx.Type.Field(i)
+		if t, ok := embeddedStruct(sf); ok {
+			if isEnclosing(ctx, t) {
+				continue
+			}
+
+			cctx := childResolutionContext(ctx, t)
+			cctx.StructField = sf
+
+			es, err := b.structSchema(cctx)
+			if err != nil {
+				return nil, err
+			}
+
+			promoted = append(promoted, es)
+			continue
+		}
+
+		propertyName, ok := b.propertyName(sf)
+		if !ok {
+			continue
+		}
+
+		cctx := childResolutionContext(ctx, sf.Type)
+		cctx.StructField = sf
+
+		ps, err := b.typeSchema(cctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = cctx.StructTagSchema(b.registry.structTagName, ps); err != nil {
+			return nil, err
+		}
+
+		if ps.IsRequired {
+			s.Required = append(s.Required, propertyName)
+		}
+		s.Properties[propertyName] = ps
+	}
+
+	// The struct's own fields take precedence over those promoted from embedded structs, and
+	// earlier embedded structs over later ones.
+	for _, es := range promoted {
+		for name, ps := range es.Properties {
+			if _, ok := s.Properties[name]; !ok {
+				s.Properties[name] = ps
+			}
+		}
+		for _, name := range es.Required {
+			if s.Properties[name] == es.Properties[name] {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// embeddedStruct returns the struct type of an embedded field whose fields are promoted into the
+// enclosing object, as encoding/json does for those without a name in their json tag.
+func embeddedStruct(sf reflect.StructField) (reflect.Type, bool) {
+	if !sf.Anonymous {
+		return nil, false
+	}
+
+	tag := sf.Tag.Get("json")
+	if tag == "-" || strings.Split(tag, ",")[0] != "" {
+		return nil, false
+	}
+
+	t := sf.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t, t.Kind() == reflect.Struct && t != tTime
+}
+
+// isEnclosing indicates whether the type is that of the ctx or one enclosing it, which embedding
+// it again would recurse forever.
+func isEnclosing(ctx ResolutionContext, t reflect.Type) bool {
+	for p := &ctx; p != nil; p = p.Parent {
+		if p.Type == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func childResolutionContext(ctx ResolutionContext, t reflect.Type) ResolutionContext {
+	cctx := ctx
+	cctx.Parent = &ctx
+	cctx.Type = t
+	return cctx
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+type schemaContributorFunc func(ResolutionContext, string, []string, *Schema) error
+
+// schemaTagValidatorFactory pairs a TagValidatorFactoryFunc with a SchemaContributor.
+type schemaTagValidatorFactory struct {
+	TagValidatorFactoryFunc
+	contribute schemaContributorFunc
+}
+
+// ContributeSchema implements the SchemaContributor interface.
+func (f schemaTagValidatorFactory) ContributeSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	return f.contribute(ctx, name, args, s)
+}
+
+func withSchema(vf TagValidatorFactoryFunc, contribute schemaContributorFunc) TagValidatorFactory {
+	return schemaTagValidatorFactory{TagValidatorFactoryFunc: vf, contribute: contribute}
+}
+
+func lengthSchema(t reflect.Type, s *Schema, min *int, max *int) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if min != nil {
+			s.MinLength = min
+		}
+		if max != nil {
+			s.MaxLength = max
+		}
+	case reflect.Array, reflect.Slice:
+		if min != nil {
+			s.MinItems = min
+		}
+		if max != nil {
+			s.MaxItems = max
+		}
+	case reflect.Map:
+		if min != nil {
+			s.MinProperties = min
+		}
+		if max != nil {
+			s.MaxProperties = max
+		}
+	}
+}
+
+func isNumber(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isScalar(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return isNumber(t) || t.Kind() == reflect.Bool || t.Kind() == reflect.String
+}
+
+// schemaValue parses the argument to a validator of the type into a value to put in a schema.
+// Values that don't marshal to the JSON they are compared as, such as times relative to now or
+// values of types with unexported fields parsed by a ParseValidateArg method, aren't reported.
+func schemaValue(t reflect.Type, arg string) (interface{}, bool, error) {
+	v, err := tryParseString(t, arg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, ok := v.(json.Marshaler); ok {
+		return v, true, nil
+	}
+
+	return v, isScalar(reflect.TypeOf(v)), nil
+}
+
+func emptySchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	lengthSchema(ctx.Type, s, nil, intPtr(0))
+	return nil
+}
+
+func equalSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	v, ok, err := schemaValue(ctx.Type, args[0])
+	if err != nil || !ok {
+		return err
+	}
+
+	s.Const = v
+	return nil
+}
+
+// formatSchema makes a contributor describing strings with the format.
+func formatSchema(format string) schemaContributorFunc {
+	return func(ctx ResolutionContext, name string, args []string, s *Schema) error {
+		s.Format = format
+		return nil
+	}
+}
+
+func greaterThanSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	if isNumber(ctx.Type) {
+		s.ExclusiveMinimum, _ = tryParseString(ctx.Type, args[0])
+	}
+	return nil
+}
+
+func greaterThanOrEqualSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	if isNumber(ctx.Type) {
+		s.Minimum, _ = tryParseString(ctx.Type, args[0])
+	}
+	return nil
+}
+
+func enumSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	values, err := isEnumAllowed(ctx, name, args)
+	if err != nil {
+		return err
+	}
+
+	s.Enum = append(s.Enum, values...)
+	return nil
+}
+
+func inSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	values := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		v, ok, err := schemaValue(ctx.Type, arg)
+		if err != nil || !ok {
+			return err
+		}
+		values = append(values, v)
+	}
+
+	s.Enum = append(s.Enum, values...)
+	return nil
+}
+
+func itemsSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	itemsTagName := "validateItems"
+	if len(args) == 1 {
+		itemsTagName = args[0]
+	}
+
+	t := ctx.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	ctx = childResolutionContext(ctx, t.Elem())
+	return ctx.StructTagSchema(itemsTagName, itemsSchemaOf(t, s))
+}
+
+// itemsSchemaOf returns the schema describing the items of the collection described by s,
+// adding it when missing.
+func itemsSchemaOf(t reflect.Type, s *Schema) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	items := &s.Items
+	if t.Kind() == reflect.Map {
+		items = &s.AdditionalProperties
+	}
+	if *items == nil {
+		*items = &Schema{}
+	}
+
+	return *items
+}
+
+func keysSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	keysTagName := "validateKeys"
+	if len(args) == 1 {
+		keysTagName = args[0]
+	}
+
+	t := ctx.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if s.PropertyNames == nil {
+		s.PropertyNames = &Schema{}
+	}
+
+	ctx = childResolutionContext(ctx, t.Key())
+	return ctx.StructTagSchema(keysTagName, s.PropertyNames)
+}
+
+func ulidSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	s.Pattern = "^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$"
+	return nil
+}
+
+func uniqueSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	t := ctx.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// JSON Schema can only describe arrays of entirely distinct items.
+	if len(args) == 0 && t.Kind() != reflect.Map {
+		s.UniqueItems = true
+	}
+
+	return nil
+}
+
+func lengthTagSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	lengthSchema(ctx.Type, s, &n, &n)
+	return nil
+}
+
+func lessThanSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	if isNumber(ctx.Type) {
+		s.ExclusiveMaximum, _ = tryParseString(ctx.Type, args[0])
+	}
+	return nil
+}
+
+func lessThanOrEqualSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	if isNumber(ctx.Type) {
+		s.Maximum, _ = tryParseString(ctx.Type, args[0])
+	}
+	return nil
+}
+
+func matchSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	s.Pattern = args[0]
+	return nil
+}
+
+func maxLengthSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	lengthSchema(ctx.Type, s, nil, &n)
+	return nil
+}
+
+func minLengthSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	lengthSchema(ctx.Type, s, &n, nil)
+	return nil
+}
+
+func notEmptySchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	lengthSchema(ctx.Type, s, intPtr(1), nil)
+	return nil
+}
+
+func notEqualSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	v, ok, err := schemaValue(ctx.Type, args[0])
+	if err != nil || !ok {
+		return err
+	}
+
+	s.Not = &Schema{Const: v}
+	return nil
+}
+
+func notNilSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	s.IsRequired = true
+	return nil
+}
+
+func notZeroSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	if isScalar(ctx.Type) && ctx.Type.Kind() != reflect.Ptr {
+		s.Not = &Schema{Const: zeroValue(ctx.Type)}
+	}
+	return nil
+}
+
+func zeroSchema(ctx ResolutionContext, name string, args []string, s *Schema) error {
+	if isScalar(ctx.Type) && ctx.Type.Kind() != reflect.Ptr {
+		s.Const = zeroValue(ctx.Type)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/validate"
+)
+
+type schemaNode struct {
+	Name     string            `json:"name" validate:"minlen(1),maxlen(10)"`
+	Age      *int              `validate:"notnil,gte(0),lt(150)"`
+	Kind     string            `validate:"in(a,b)|empty"`
+	Children []*schemaNode     `validate:"items" validateItems:"notnil,struct"`
+	Labels   map[string]string `validate:"len(2),keys" validateKeys:"minlen(1)"`
+	Scores   [][]int           `validate:"items(vi)" vi:"items(vi2)" vi2:"gt(3)"`
+	Grid     [][]string        `validate:"minlen(1),dive,dive,notempty"`
+	ignored  string            `validate:"notempty"`
+}
+
+func TestRegistry_JSONSchema(t *testing.T) {
+	s, err := validate.DefaultRegistry.JSONSchema(reflect.TypeOf(schemaNode{}))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	actual, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","$ref":"#/$defs/schemaNode","$defs":{"schemaNode":{"type":"object","properties":{` +
+		`"Age":{"type":"integer","minimum":0,"exclusiveMaximum":150},` +
+		`"Children":{"type":"array","items":{"$ref":"#/$defs/schemaNode"}},` +
+		`"Grid":{"type":"array","items":{"type":"array","items":{"type":"string","minLength":1}},"minItems":1},` +
+		`"Kind":{"type":"string","anyOf":[{"enum":["a","b"]},{"maxLength":0}]},` +
+		`"Labels":{"type":"object","additionalProperties":{"type":"string"},"propertyNames":{"minLength":1},"minProperties":2,"maxProperties":2},` +
+		`"Scores":{"type":"array","items":{"type":"array","items":{"type":"integer","exclusiveMinimum":3}}},` +
+		`"name":{"type":"string","minLength":1,"maxLength":10}},` +
+		`"required":["Age"]}}}`
+	if string(actual) != expected {
+		t.Fatalf("expected schema\n%s\nbut got\n%s", expected, actual)
+	}
+}
+
+func TestRegistry_JSONSchema_Time(t *testing.T) {
+	s, err := validate.DefaultRegistry.JSONSchema(reflect.TypeOf(struct {
+		At      time.Time     `validate:"future"`
+		Now     time.Time     `validate:"eq(now)"`
+		Later   time.Time     `validate:"neq(now+1h),in(now, 2024-01-01T00:00:00Z)"`
+		Epoch   time.Time     `validate:"eq(1970-01-01T00:00:00Z)"`
+		Timeout time.Duration `validate:"gt(1s),lte(1m)"`
+	}{}))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	actual, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+		`"At":{"type":"string","format":"date-time"},` +
+		`"Epoch":{"type":"string","const":"1970-01-01T00:00:00Z","format":"date-time"},` +
+		`"Later":{"type":"string","format":"date-time"},` +
+		`"Now":{"type":"string","format":"date-time"},` +
+		`"Timeout":{"type":"integer","description":"duration in nanoseconds","maximum":60000000000,"exclusiveMinimum":1000000000}}}`
+	if string(actual) != expected {
+		t.Fatalf("expected schema\n%s\nbut got\n%s", expected, actual)
+	}
+}
+
+type schemaBase struct {
+	ID    string `json:"id" validate:"notempty"`
+	Title string `json:"title"`
+}
+
+type schemaAudit struct {
+	Author *string `json:"author" validate:"notnil"`
+}
+
+type schemaDocument struct {
+	schemaBase
+	*schemaAudit
+	Title  string     `json:"title" validate:"maxlen(80)"`
+	Parent schemaBase `json:"parent"`
+}
+
+func TestRegistry_JSONSchema_Embedded(t *testing.T) {
+	s, err := validate.DefaultRegistry.JSONSchema(reflect.TypeOf(schemaDocument{}))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	actual, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","$ref":"#/$defs/schemaDocument","$defs":{` +
+		`"schemaBase":{"type":"object","properties":{"id":{"type":"string","minLength":1},"title":{"type":"string"}}},` +
+		`"schemaDocument":{"type":"object","properties":{` +
+		`"author":{"type":"string"},` +
+		`"id":{"type":"string","minLength":1},` +
+		`"parent":{"$ref":"#/$defs/schemaBase"},` +
+		`"title":{"type":"string","maxLength":80}},` +
+		`"required":["author"]}}}`
+	if string(actual) != expected {
+		t.Fatalf("expected schema\n%s\nbut got\n%s", expected, actual)
+	}
+}
+
+type openAPIAddress struct {
+	City string `json:"city" validate:"notempty~city is required"`
+	Zip  string `json:"zip,omitempty" validate:"-"`
+}
+
+type openAPIPerson struct {
+	Name    string          `json:"name" validate:"maxlen(20)"`
+	Address *openAPIAddress `json:"address" validate:"notnil,struct"`
+	Secret  string          `json:"-" validate:"notempty"`
+	Nick    string
+}
+
+func TestRegistry_OpenAPIComponents(t *testing.T) {
+	c, err := validate.DefaultRegistry.OpenAPIComponents(reflect.TypeOf(&openAPIPerson{}))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	actual, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	expected := `{"schemas":{` +
+		`"openAPIAddress":{"type":"object","properties":{"city":{"type":"string","description":"city is required","minLength":1},"zip":{"type":"string"}}},` +
+		`"openAPIPerson":{"type":"object","properties":{"Nick":{"type":"string"},"address":{"$ref":"#/components/schemas/openAPIAddress"},"name":{"type":"string","maxLength":20}},"required":["address"]}}}`
+	if string(actual) != expected {
+		t.Fatalf("expected components\n%s\nbut got\n%s", expected, actual)
+	}
+}
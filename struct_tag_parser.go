@@ -1,7 +1,7 @@
 package validate
 
 import (
-	"fmt"
+	"reflect"
 	"strings"
 	"unicode"
 )
@@ -28,14 +28,6 @@ type StructTagParseResult struct {
 	CustomMessage string
 }
 
-type pState uint8
-
-const (
-	none pState = iota
-	name
-	args
-)
-
 // DefaultStructTagParser is the default StructTagParser.
 var DefaultStructTagParser StructTagParserFunc = func(ctx ResolutionContext, tagName string) (*StructTagParseResult, error) {
 	tag, ok := ctx.StructField.Tag.Lookup(tagName)
@@ -43,94 +35,553 @@ var DefaultStructTagParser StructTagParserFunc = func(ctx ResolutionContext, tag
 		return &StructTagParseResult{Validator: NoOpValidator{}}, nil
 	}
 
-	defAndMessage := strings.SplitN(tag, "~", 2)
-
-	parts := strings.Split(defAndMessage[0], `|`)
+	st, err := parseStructTagFor(ctx, tagName, tag)
+	if err != nil {
+		return nil, err
+	}
 
 	var validators []Validator
-	for _, disjunctionPart := range parts {
-		v, err := parseTag(ctx, []rune(disjunctionPart))
+	var failed bool
+	for _, section := range st.sections {
+		// Compiling checks the sections of every group, though only those applying in the
+		// groups being resolved for are part of the validator.
+		applies := ctx.inGroups(section.groups)
+		if !applies && ctx.compilation == nil {
+			continue
+		}
+
+		v, err := buildDisjunction(ctx, section.disjunctions)
 		if err != nil {
-			return nil, err
+			if !ctx.collect(err) {
+				return nil, err
+			}
+			failed = true
+			continue
 		}
 
-		validators = append(validators, v)
+		if applies {
+			validators = append(validators, v)
+		}
 	}
 
-	stpr := &StructTagParseResult{Validator: Or(validators...)}
+	if failed {
+		return nil, errReported
+	}
 
-	if len(defAndMessage) == 2 {
-		stpr.CustomMessage = defAndMessage[1]
+	var validator Validator
+	switch len(validators) {
+	case 0:
+		validator = NoOpValidator{}
+	case 1:
+		validator = validators[0]
+	default:
+		validator = And(validators...)
 	}
 
-	return stpr, nil
+	return &StructTagParseResult{
+		Validator:     validator,
+		CustomMessage: st.customMessage,
+	}, nil
 }
 
-func parseTag(ctx ResolutionContext, tag []rune) (Validator, error) {
-	state := none
+// buildDisjunction builds the conjunctions, reporting the errors of each when compiling.
+func buildDisjunction(ctx ResolutionContext, disjunctions [][]tagValidator) (Validator, error) {
+	var disjuncts []Validator
+	var failed bool
+	for _, conjunction := range disjunctions {
+		v, err := buildConjunction(ctx, conjunction)
+		if err != nil {
+			if !ctx.collect(err) {
+				return nil, err
+			}
+			failed = true
+			continue
+		}
 
-	var validators []Validator
+		disjuncts = append(disjuncts, v)
+	}
 
-	var validatorName string
-	var validatorArg string
-	var validatorArgs []string
-
-	for i := 0; i < len(tag)+1; i++ {
-		var c rune
-		if i == len(tag) {
-			c = 0
-		} else {
-			c = tag[i]
-		}
-
-		switch state {
-		case none:
-			switch {
-			case c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c):
-				state = name
-				validatorName += string(c)
-			case unicode.IsSpace(c):
-			case c == '(':
-				state = args
-			case c == ',' || c == 0:
-				vf, err := ctx.LookupTagValidatorFactory(validatorName)
-				if err != nil {
-					return nil, err
-				}
+	if failed {
+		return nil, errReported
+	}
+
+	return Or(disjuncts...), nil
+}
 
-				v, err := vf.Create(ctx, validatorName, validatorArgs)
-				if err != nil {
+func buildConjunction(ctx ResolutionContext, conjunction []tagValidator) (Validator, error) {
+	var validators []Validator
+	var failed bool
+	for _, tv := range conjunction {
+		if tv.name == diveKeyword {
+			v, err := buildDive(ctx, tv)
+			if err != nil {
+				if !ctx.collect(err) {
 					return nil, err
 				}
-
-				validators = append(validators, v)
-				state = none
-				validatorName = ""
-			default:
-				return nil, fmt.Errorf("invalid character %q", c)
+				failed = true
+				break
 			}
-		case name:
-			switch {
-			case c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c):
-				validatorName += string(c)
-			default:
-				state = none
-				i--
+
+			validators = append(validators, v)
+			break
+		}
+
+		vf, err := ctx.LookupTagValidatorFactory(tv.name)
+		if err != nil {
+			if !ctx.collect(err) {
+				return nil, err
 			}
-		case args:
-			switch c {
-			case ',':
-				validatorArgs = append(validatorArgs, validatorArg)
-				validatorArg = ""
-			case ')':
-				validatorArgs = append(validatorArgs, validatorArg)
-				validatorArg = ""
-				state = none
-			default:
-				validatorArg += string(c)
+			failed = true
+			continue
+		}
+
+		v, err := vf.Create(ctx, tv.name, tv.args)
+		if err != nil {
+			if !ctx.collect(err) {
+				return nil, err
 			}
+			failed = true
+			continue
+		}
+
+		if tv.severity != SeverityUnspecified {
+			v = AtSeverity(v, tv.severity)
 		}
+
+		validators = append(validators, v)
+	}
+
+	if failed {
+		return nil, errReported
 	}
 
 	return And(validators...), nil
 }
+
+// diveKeyword separates the validators for a collection from those for its items.
+const diveKeyword = "dive"
+
+// buildDive builds a validator applying the rules following a dive to the items of the
+// collection. Those may contain a dive of their own to reach further into nested collections.
+func buildDive(ctx ResolutionContext, tv tagValidator) (Validator, error) {
+	ictx, err := diveResolutionContext(ctx, tv)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := buildDisjunction(ictx, tv.items)
+	if err != nil {
+		return nil, err
+	}
+
+	v = Items(v)
+	if tv.severity != SeverityUnspecified {
+		v = AtSeverity(v, tv.severity)
+	}
+
+	return v, nil
+}
+
+func diveResolutionContext(ctx ResolutionContext, tv tagValidator) (ResolutionContext, error) {
+	if err := isItemsAllowed(ctx.Type, tv.name, tv.args); err != nil {
+		return ctx, err
+	}
+	if len(tv.args) > 0 {
+		return ctx, InvalidTagArgumentsError{Message: "no arguments are allowed", ValidatorName: tv.name, Args: tv.args}
+	}
+
+	t := ctx.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return childResolutionContext(ctx, t.Elem()), nil
+}
+
+// structTag is a struct tag parsed with the default grammar.
+type structTag struct {
+	sections      []tagSection
+	customMessage string
+}
+
+// tagSection is a disjunction of rules within a struct tag that applies only in its groups,
+// or always when it has none.
+type tagSection struct {
+	groups       []string
+	disjunctions [][]tagValidator
+}
+
+// tagValidator is a single named validator and its arguments within a struct tag.
+type tagValidator struct {
+	name     string
+	args     []string
+	severity Severity
+	// items is the disjunction applying to the items of the collection, for a dive.
+	items [][]tagValidator
+}
+
+// parseStructTag parses a tag with the default grammar:
+//
+//	tag         = section { ";" section } [ "~" message ]
+//	section     = [ group { "," group } ":" ] disjunction
+//	disjunction = conjunction { "|" conjunction }
+//	conjunction = { validator "," } ( "dive" [ "," disjunction ] | validator )
+//	validator   = name [ "(" [ arg { "," arg } ] ")" ] [ "!" severity ]
+//	severity    = "info" | "warn" | "warning" | "error" | "fatal"
+//
+// An arg is either quoted with ' or ", in which case a backslash escapes a quote or another
+// backslash, or is unquoted, in which case it may contain balanced parentheses. Whitespace
+// around names and args is ignored and the message is taken verbatim.
+//
+// The rules following a "dive" apply to the items of a slice, array, or map rather than to the
+// collection itself. A "|" after a dive also applies to the items, so "dive,minlen(3)|empty"
+// requires each item to have a length of at least 3 or to be empty.
+//
+// A section with groups only applies when validating with one of them, while one without
+// applies always. Malformed tags produce a TagSyntaxError.
+func parseStructTag(tag string) (*structTag, error) {
+	p := &tagParser{tag: []rune(tag)}
+
+	st := &structTag{}
+	for {
+		section := tagSection{groups: p.parseGroups()}
+		disjunctions, err := p.parseDisjunction()
+		if err != nil {
+			return nil, err
+		}
+
+		section.disjunctions = disjunctions
+		st.sections = append(st.sections, section)
+
+		switch p.peek() {
+		case ';':
+			p.pos++
+		case '~':
+			st.customMessage = string(p.tag[p.pos+1:])
+			return st, nil
+		case eof:
+			return st, nil
+		default:
+			return nil, p.syntaxError(`",", "|", ";", or "~"`)
+		}
+	}
+}
+
+// StructTag is a struct tag parsed with the grammar of the DefaultStructTagParser. It's used by
+// tools that work with struct tags without resolving validators, such as code generators.
+type StructTag struct {
+	Sections      []StructTagSection
+	CustomMessage string
+}
+
+// StructTagSection is a disjunction of conjunctions of rules that applies only in its Groups,
+// or always when it has none.
+type StructTagSection struct {
+	Groups       []string
+	Disjunctions [][]StructTagRule
+}
+
+// StructTagRule is a single named validator within a struct tag. For a dive, Items holds the
+// disjunction of conjunctions applying to the items of the collection.
+type StructTagRule struct {
+	Name     string
+	Args     []string
+	Severity Severity
+	Items    [][]StructTagRule
+}
+
+// ParseStructTag parses a tag with the grammar of the DefaultStructTagParser. Malformed tags
+// produce a TagSyntaxError.
+func ParseStructTag(tag string) (*StructTag, error) {
+	st, err := parseStructTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StructTag{CustomMessage: st.customMessage}
+	for _, section := range st.sections {
+		result.Sections = append(result.Sections, StructTagSection{
+			Groups:       section.groups,
+			Disjunctions: structTagRules(section.disjunctions),
+		})
+	}
+
+	return result, nil
+}
+
+func structTagRules(disjunctions [][]tagValidator) [][]StructTagRule {
+	var result [][]StructTagRule
+	for _, conjunction := range disjunctions {
+		rules := make([]StructTagRule, len(conjunction))
+		for i, tv := range conjunction {
+			rules[i] = StructTagRule{Name: tv.name, Args: tv.args, Severity: tv.severity, Items: structTagRules(tv.items)}
+		}
+		result = append(result, rules)
+	}
+
+	return result
+}
+
+// parseStructTagFor parses a tag for the struct field of the ctx, adding the field's
+// information to any TagSyntaxError.
+func parseStructTagFor(ctx ResolutionContext, tagName string, tag string) (*structTag, error) {
+	st, err := parseStructTag(tag)
+	if se, ok := err.(TagSyntaxError); ok {
+		for p := ctx.Parent; p != nil; p = p.Parent {
+			if p.Type.Kind() == reflect.Struct {
+				se.Type = p.Type
+				break
+			}
+		}
+		se.Field = ctx.StructField.Name
+		se.TagName = tagName
+		return nil, se
+	}
+
+	return st, err
+}
+
+const eof rune = -1
+
+type tagParser struct {
+	tag []rune
+	pos int
+}
+
+func (p *tagParser) peek() rune {
+	if p.pos >= len(p.tag) {
+		return eof
+	}
+
+	return p.tag[p.pos]
+}
+
+func (p *tagParser) next() rune {
+	c := p.peek()
+	if c != eof {
+		p.pos++
+	}
+
+	return c
+}
+
+func (p *tagParser) syntaxError(expected string) error {
+	return TagSyntaxError{
+		Tag:      string(p.tag),
+		Offset:   len(string(p.tag[:p.pos])),
+		Expected: expected,
+	}
+}
+
+func (p *tagParser) skipSpace() {
+	for unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// parseGroups parses the groups of a section, if it has any. Nothing is consumed otherwise.
+func (p *tagParser) parseGroups() []string {
+	start := p.pos
+
+	var groups []string
+	for {
+		name, ok := p.parseName()
+		if !ok {
+			break
+		}
+		groups = append(groups, name)
+
+		p.skipSpace()
+		c := p.next()
+		if c == ':' {
+			return groups
+		}
+		if c != ',' {
+			break
+		}
+	}
+
+	p.pos = start
+	return nil
+}
+
+func (p *tagParser) parseName() (string, bool) {
+	p.skipSpace()
+
+	start := p.pos
+	for c := p.peek(); c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c); c = p.peek() {
+		p.pos++
+	}
+
+	return string(p.tag[start:p.pos]), start != p.pos
+}
+
+func (p *tagParser) parseDisjunction() ([][]tagValidator, error) {
+	var disjunctions [][]tagValidator
+	for {
+		conjunction, err := p.parseConjunction()
+		if err != nil {
+			return nil, err
+		}
+
+		disjunctions = append(disjunctions, conjunction)
+
+		if p.peek() != '|' {
+			return disjunctions, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *tagParser) parseConjunction() ([]tagValidator, error) {
+	var validators []tagValidator
+	for {
+		tv, err := p.parseValidator()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ',' {
+			return append(validators, tv), nil
+		}
+		p.pos++
+
+		// The rest of the section, including its alternatives, applies to the items.
+		if tv.name == diveKeyword {
+			tv.items, err = p.parseDisjunction()
+			if err != nil {
+				return nil, err
+			}
+			return append(validators, tv), nil
+		}
+
+		validators = append(validators, tv)
+	}
+}
+
+func (p *tagParser) parseValidator() (tagValidator, error) {
+	name, ok := p.parseName()
+	if !ok {
+		return tagValidator{}, p.syntaxError("validator name")
+	}
+
+	tv := tagValidator{name: name}
+
+	p.skipSpace()
+	if p.peek() == '(' {
+		p.pos++
+		args, err := p.parseArgs()
+		if err != nil {
+			return tv, err
+		}
+		tv.args = args
+		p.skipSpace()
+	}
+
+	if p.peek() == '!' {
+		p.pos++
+		p.skipSpace()
+
+		start := p.pos
+		for unicode.IsLetter(p.peek()) {
+			p.pos++
+		}
+
+		s, ok := parseSeverity(string(p.tag[start:p.pos]))
+		if !ok {
+			p.pos = start
+			return tv, p.syntaxError(`"info", "warn", "error", or "fatal"`)
+		}
+		tv.severity = s
+		p.skipSpace()
+	}
+
+	return tv, nil
+}
+
+func (p *tagParser) parseArgs() ([]string, error) {
+	var args []string
+	for {
+		arg, quoted, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+
+		switch p.next() {
+		case ',':
+			args = append(args, arg)
+		case ')':
+			// An empty argument list, "()", has no arguments.
+			if len(args) > 0 || quoted || arg != "" {
+				args = append(args, arg)
+			}
+			return args, nil
+		default:
+			return nil, p.syntaxError(`"," or ")"`)
+		}
+	}
+}
+
+func (p *tagParser) parseArg() (string, bool, error) {
+	p.skipSpace()
+
+	if c := p.peek(); c == '\'' || c == '"' {
+		arg, err := p.parseQuoted(c)
+		if err != nil {
+			return "", true, err
+		}
+
+		p.skipSpace()
+		if c := p.peek(); c != ',' && c != ')' {
+			return "", true, p.syntaxError(`"," or ")"`)
+		}
+
+		return arg, true, nil
+	}
+
+	start := p.pos
+	depth := 0
+	for {
+		switch p.peek() {
+		case eof:
+			if depth > 0 {
+				return "", false, p.syntaxError(`")"`)
+			}
+			return strings.TrimSpace(string(p.tag[start:p.pos])), false, nil
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return strings.TrimSpace(string(p.tag[start:p.pos])), false, nil
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return strings.TrimSpace(string(p.tag[start:p.pos])), false, nil
+			}
+		}
+		p.pos++
+	}
+}
+
+func (p *tagParser) parseQuoted(quote rune) (string, error) {
+	p.pos++
+
+	var sb strings.Builder
+	for {
+		c := p.next()
+		switch c {
+		case eof:
+			return "", p.syntaxError("closing " + string(quote))
+		case quote:
+			return sb.String(), nil
+		case '\\':
+			// Only quotes and backslashes are escaped, leaving other sequences, such as
+			// those in regular expressions, untouched.
+			if n := p.peek(); n == '\'' || n == '"' || n == '\\' {
+				c = p.next()
+			}
+		}
+		sb.WriteRune(c)
+	}
+}
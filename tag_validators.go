@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"reflect"
 	"strconv"
 )
 
@@ -18,29 +19,124 @@ func (f TagValidatorFactoryFunc) Create(ctx ResolutionContext, name string, args
 }
 
 // RegisterDefaultTagValidatorFactories registers all the default validators into the RegistryBuilder.
+// Validators that JSON Schema has no format for, such as the network validators cidr, hostport,
+// ip, mac, and port, and those depending on other fields or on when validation is performed,
+// don't contribute to schemas.
 func RegisterDefaultTagValidatorFactories(rb *RegistryBuilder) *RegistryBuilder {
-	rb.RegisterTagValidatorFactory("empty", TagValidatorFactoryFunc(EmptyFactory))
-	rb.RegisterTagValidatorFactory("eq", TagValidatorFactoryFunc(EqualFactory))
-	rb.RegisterTagValidatorFactory("gt", TagValidatorFactoryFunc(GreaterThanFactory))
-	rb.RegisterTagValidatorFactory("gte", TagValidatorFactoryFunc(GreaterThanOrEqualFactory))
-	rb.RegisterTagValidatorFactory("in", TagValidatorFactoryFunc(InFactory))
-	rb.RegisterTagValidatorFactory("items", TagValidatorFactoryFunc(ItemsFactory))
-	rb.RegisterTagValidatorFactory("len", TagValidatorFactoryFunc(LengthFactory))
-	rb.RegisterTagValidatorFactory("lt", TagValidatorFactoryFunc(LessThanFactory))
-	rb.RegisterTagValidatorFactory("lte", TagValidatorFactoryFunc(LessThanOrEqualFactory))
-	rb.RegisterTagValidatorFactory("maxlen", TagValidatorFactoryFunc(MaxLengthFactory))
-	rb.RegisterTagValidatorFactory("minlen", TagValidatorFactoryFunc(MinLengthFactory))
-	rb.RegisterTagValidatorFactory("neq", TagValidatorFactoryFunc(NotEqualFactory))
+	rb.RegisterTagValidatorFactory("after", TagValidatorFactoryFunc(AfterFactory))
+	rb.RegisterTagValidatorFactory("before", TagValidatorFactoryFunc(BeforeFactory))
+	rb.RegisterTagValidatorFactory("cidr", TagValidatorFactoryFunc(CIDRFactory))
+	rb.RegisterTagValidatorFactory("cidr_contains", TagValidatorFactoryFunc(CIDRContainsFactory))
+	rb.RegisterTagValidatorFactory("email", withSchema(EmailFactory, formatSchema("email")))
+	rb.RegisterTagValidatorFactory("empty", withSchema(EmptyFactory, emptySchema))
+	rb.RegisterTagValidatorFactory("enum", withSchema(EnumFactory, enumSchema))
+	rb.RegisterTagValidatorFactory("eq", withSchema(EqualFactory, equalSchema))
+	rb.RegisterTagValidatorFactory("eqfield", TagValidatorFactoryFunc(EqualFieldFactory))
+	rb.RegisterTagValidatorFactory("excluded_if", TagValidatorFactoryFunc(ExcludedIfFactory))
+	rb.RegisterTagValidatorFactory("excluded_with", TagValidatorFactoryFunc(ExcludedWithFactory))
+	rb.RegisterTagValidatorFactory("fqdn", withSchema(FQDNFactory, formatSchema("hostname")))
+	rb.RegisterTagValidatorFactory("future", TagValidatorFactoryFunc(FutureFactory))
+	rb.RegisterTagValidatorFactory("gt", withSchema(GreaterThanFactory, greaterThanSchema))
+	rb.RegisterTagValidatorFactory("gte", withSchema(GreaterThanOrEqualFactory, greaterThanOrEqualSchema))
+	rb.RegisterTagValidatorFactory("gtefield", TagValidatorFactoryFunc(GreaterThanOrEqualFieldFactory))
+	rb.RegisterTagValidatorFactory("gtfield", TagValidatorFactoryFunc(GreaterThanFieldFactory))
+	rb.RegisterTagValidatorFactory("hostname", withSchema(HostnameFactory, formatSchema("hostname")))
+	rb.RegisterTagValidatorFactory("hostport", TagValidatorFactoryFunc(HostPortFactory))
+	rb.RegisterTagValidatorFactory("in", withSchema(InFactory, inSchema))
+	rb.RegisterTagValidatorFactory("ip", TagValidatorFactoryFunc(IPFactory))
+	rb.RegisterTagValidatorFactory("ipv4", withSchema(IPv4Factory, formatSchema("ipv4")))
+	rb.RegisterTagValidatorFactory("ipv6", withSchema(IPv6Factory, formatSchema("ipv6")))
+	rb.RegisterTagValidatorFactory("items", withSchema(ItemsFactory, itemsSchema))
+	rb.RegisterTagValidatorFactory("keys", withSchema(KeysFactory, keysSchema))
+	rb.RegisterTagValidatorFactory("len", withSchema(LengthFactory, lengthTagSchema))
+	rb.RegisterTagValidatorFactory("lt", withSchema(LessThanFactory, lessThanSchema))
+	rb.RegisterTagValidatorFactory("lte", withSchema(LessThanOrEqualFactory, lessThanOrEqualSchema))
+	rb.RegisterTagValidatorFactory("ltefield", TagValidatorFactoryFunc(LessThanOrEqualFieldFactory))
+	rb.RegisterTagValidatorFactory("ltfield", TagValidatorFactoryFunc(LessThanFieldFactory))
+	rb.RegisterTagValidatorFactory("mac", TagValidatorFactoryFunc(MACFactory))
+	rb.RegisterTagValidatorFactory("match", withSchema(MatchFactory, matchSchema))
+	rb.RegisterTagValidatorFactory("maxlen", withSchema(MaxLengthFactory, maxLengthSchema))
+	rb.RegisterTagValidatorFactory("minlen", withSchema(MinLengthFactory, minLengthSchema))
+	rb.RegisterTagValidatorFactory("nefield", TagValidatorFactoryFunc(NotEqualFieldFactory))
+	rb.RegisterTagValidatorFactory("neq", withSchema(NotEqualFactory, notEqualSchema))
 	rb.RegisterTagValidatorFactory("nil", TagValidatorFactoryFunc(NilFactory))
-	rb.RegisterTagValidatorFactory("notempty", TagValidatorFactoryFunc(NotEmptyFactory))
-	rb.RegisterTagValidatorFactory("notnil", TagValidatorFactoryFunc(NotNilFactory))
-	rb.RegisterTagValidatorFactory("notzero", TagValidatorFactoryFunc(NotZeroFactory))
+	rb.RegisterTagValidatorFactory("notempty", withSchema(NotEmptyFactory, notEmptySchema))
+	rb.RegisterTagValidatorFactory("notnil", withSchema(NotNilFactory, notNilSchema))
+	rb.RegisterTagValidatorFactory("notzero", withSchema(NotZeroFactory, notZeroSchema))
+	rb.RegisterTagValidatorFactory("past", TagValidatorFactoryFunc(PastFactory))
+	rb.RegisterTagValidatorFactory("port", TagValidatorFactoryFunc(PortFactory))
+	rb.RegisterTagValidatorFactory("regex", withSchema(MatchFactory, matchSchema))
+	rb.RegisterTagValidatorFactory("required_if", TagValidatorFactoryFunc(RequiredIfFactory))
+	rb.RegisterTagValidatorFactory("required_unless", TagValidatorFactoryFunc(RequiredUnlessFactory))
+	rb.RegisterTagValidatorFactory("required_with", TagValidatorFactoryFunc(RequiredWithFactory))
+	rb.RegisterTagValidatorFactory("required_without", TagValidatorFactoryFunc(RequiredWithoutFactory))
 	rb.RegisterTagValidatorFactory("struct", TagValidatorFactoryFunc(StructFactory))
-	rb.RegisterTagValidatorFactory("zero", TagValidatorFactoryFunc(ZeroFactory))
+	rb.RegisterTagValidatorFactory("ulid", withSchema(ULIDFactory, ulidSchema))
+	rb.RegisterTagValidatorFactory("unique", withSchema(UniqueFactory, uniqueSchema))
+	rb.RegisterTagValidatorFactory("uri", withSchema(URIFactory, formatSchema("uri")))
+	rb.RegisterTagValidatorFactory("url", withSchema(URLFactory, formatSchema("uri")))
+	rb.RegisterTagValidatorFactory("uuid", withSchema(UUIDFactory, formatSchema("uuid")))
+	rb.RegisterTagValidatorFactory("within", TagValidatorFactoryFunc(WithinFactory))
+	rb.RegisterTagValidatorFactory("zero", withSchema(ZeroFactory, zeroSchema))
 
 	return rb
 }
 
+// AfterFactory generates a Validator that requires a time to be after an RFC 3339 time or a
+// time relative to now, such as "now+24h".
+func AfterFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isTimeArgAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	v, _ := parseTimeArg(args[0])
+	return after(v), nil
+}
+
+// BeforeFactory generates a Validator that requires a time to be before an RFC 3339 time or a
+// time relative to now, such as "now+24h".
+func BeforeFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isTimeArgAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	v, _ := parseTimeArg(args[0])
+	return before(v), nil
+}
+
+// CIDRFactory generates a Validator that requires a string or net.IPNet to be an IP network in CIDR notation.
+func CIDRFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgNetworkAllowed(ctx.Type, name, args, isCIDRAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return CIDR(), nil
+}
+
+// CIDRContainsFactory generates a Validator that requires an IP address or network to be within
+// the network specified in CIDR notation.
+func CIDRContainsFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	block, err := cidrContainsBlock(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return CIDRContains(block), nil
+}
+
+// EmailFactory generates a Validator that requires a string to be an email address.
+func EmailFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgStringFormatAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return Email(), nil
+}
+
 // EmptyFactory generates a Validator that requires the length of a string, array, slice, or map to be 0.
 func EmptyFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
 	err := isEmptyAllowed(ctx.Type, name, args)
@@ -51,6 +147,18 @@ func EmptyFactory(ctx ResolutionContext, name string, args []string) (Validator,
 	return Empty(), nil
 }
 
+// EnumFactory generates a Validator that requires a value to be one of the values registered
+// for its type with RegisterEnum, or returned by its Values method. Arguments restrict the
+// values to those named.
+func EnumFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	values, err := isEnumAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return Enum(values...), nil
+}
+
 // EqualFactory generates a Validator that requires a value to be equal to a specified other.
 func EqualFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
 	err := isCmpAllowed(ctx.Type, name, args)
@@ -60,7 +168,57 @@ func EqualFactory(ctx ResolutionContext, name string, args []string) (Validator,
 
 	v, _ := tryParseString(ctx.Type, args[0])
 
-	return Equal(v), nil
+	return equal(v, comparerOf(ctx.Type)), nil
+}
+
+// EqualFieldFactory generates a Validator that requires a value to be equal to a referenced field.
+func EqualFieldFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isFieldCmpAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return equalField(args[0], comparerOf(ctx.Type)), nil
+}
+
+// ExcludedIfFactory generates a Validator that requires a value to be absent when a referenced field is equal to a specified value.
+func ExcludedIfFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	v, err := isPresenceIfAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExcludedIf(args[0], v), nil
+}
+
+// ExcludedWithFactory generates a Validator that requires a value to be absent when any of the referenced fields are present.
+func ExcludedWithFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isPresenceWithAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExcludedWith(args...), nil
+}
+
+// FQDNFactory generates a Validator that requires a string to be a fully qualified domain name.
+func FQDNFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgStringFormatAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return FQDN(), nil
+}
+
+// FutureFactory generates a Validator that requires a time to be after now.
+func FutureFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgTimeAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return Future(), nil
 }
 
 // GreaterThanFactory generates a Validator that requires a value to be greater than a specified other.
@@ -72,7 +230,17 @@ func GreaterThanFactory(ctx ResolutionContext, name string, args []string) (Vali
 
 	v, _ := tryParseString(ctx.Type, args[0])
 
-	return GreaterThan(v), nil
+	return greaterThan(v, comparerOf(ctx.Type)), nil
+}
+
+// GreaterThanFieldFactory generates a Validator that requires a value to be greater than a referenced field.
+func GreaterThanFieldFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isFieldCmpAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return greaterThanField(args[0], comparerOf(ctx.Type)), nil
 }
 
 // GreaterThanOrEqualFactory generates a Validator that requires a value to be greater than or equal to a specified other.
@@ -84,7 +252,37 @@ func GreaterThanOrEqualFactory(ctx ResolutionContext, name string, args []string
 
 	v, _ := tryParseString(ctx.Type, args[0])
 
-	return GreaterThanOrEqual(v), nil
+	return greaterThanOrEqual(v, comparerOf(ctx.Type)), nil
+}
+
+// GreaterThanOrEqualFieldFactory generates a Validator that requires a value to be greater than or equal to a referenced field.
+func GreaterThanOrEqualFieldFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isFieldCmpAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return greaterThanOrEqualField(args[0], comparerOf(ctx.Type)), nil
+}
+
+// HostPortFactory generates a Validator that requires a string to be a host and port.
+func HostPortFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgNetworkAllowed(ctx.Type, name, args, isHostPortAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return HostPort(), nil
+}
+
+// HostnameFactory generates a Validator that requires a string to be a hostname.
+func HostnameFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgStringFormatAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return Hostname(), nil
 }
 
 // InFactory generates a Validator that requires a value to be on of a listof specified values.
@@ -99,7 +297,71 @@ func InFactory(ctx ResolutionContext, name string, args []string) (Validator, er
 		vs = append(vs, v)
 	}
 
-	return In(vs...), nil
+	return in(vs, comparerOf(ctx.Type)), nil
+}
+
+// IPFactory generates a Validator that requires a string or net.IP to be an IP address.
+func IPFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgNetworkAllowed(ctx.Type, name, args, isIPAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return IP(), nil
+}
+
+// IPv4Factory generates a Validator that requires a string or net.IP to be an IPv4 address.
+func IPv4Factory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgNetworkAllowed(ctx.Type, name, args, isIPAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return IPv4(), nil
+}
+
+// IPv6Factory generates a Validator that requires a string or net.IP to be an IPv6 address.
+func IPv6Factory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgNetworkAllowed(ctx.Type, name, args, isIPAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return IPv6(), nil
+}
+
+// KeysFactory generates a Validator for keys of a map.
+func KeysFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isKeysAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	keysTagName := "validateKeys"
+	if len(args) == 1 {
+		keysTagName = args[0]
+	}
+
+	t := ctx.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	ctx = childResolutionContext(ctx, t.Key())
+
+	stpr, err := ctx.ParseStructTags(keysTagName)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := stpr.Validator
+	if validator == nil {
+		return NoOpValidator{}, nil
+	}
+	if stpr.CustomMessage != "" {
+		validator = CustomMessage(validator, stpr.CustomMessage)
+	}
+
+	return Keys(validator), nil
 }
 
 // ItemsFactory generates a Validator for items in a slice or array or values of a map.
@@ -114,8 +376,11 @@ func ItemsFactory(ctx ResolutionContext, name string, args []string) (Validator,
 		itemsTagName = args[0]
 	}
 
-	itemType := ctx.Type.Elem()
-	ctx.Type = itemType
+	t := ctx.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	ctx = childResolutionContext(ctx, t.Elem())
 
 	stpr, err := ctx.ParseStructTags(itemsTagName)
 	if err != nil {
@@ -153,7 +418,17 @@ func LessThanFactory(ctx ResolutionContext, name string, args []string) (Validat
 
 	v, _ := tryParseString(ctx.Type, args[0])
 
-	return LessThan(v), nil
+	return lessThan(v, comparerOf(ctx.Type)), nil
+}
+
+// LessThanFieldFactory generates a Validator that requires a value to be less than a referenced field.
+func LessThanFieldFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isFieldCmpAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return lessThanField(args[0], comparerOf(ctx.Type)), nil
 }
 
 // LessThanOrEqualFactory generates a Validator that requires a value to be less than or equal to a specified other.
@@ -165,7 +440,39 @@ func LessThanOrEqualFactory(ctx ResolutionContext, name string, args []string) (
 
 	v, _ := tryParseString(ctx.Type, args[0])
 
-	return LessThanOrEqual(v), nil
+	return lessThanOrEqual(v, comparerOf(ctx.Type)), nil
+}
+
+// LessThanOrEqualFieldFactory generates a Validator that requires a value to be less than or equal to a referenced field.
+func LessThanOrEqualFieldFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isFieldCmpAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return lessThanOrEqualField(args[0], comparerOf(ctx.Type)), nil
+}
+
+// MACFactory generates a Validator that requires a string or net.HardwareAddr to be a MAC address.
+func MACFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgNetworkAllowed(ctx.Type, name, args, isMACAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return MAC(), nil
+}
+
+// MatchFactory generates a Validator that requires a string to match a regular expression. The
+// expression is compiled once, so an invalid one is reported when the validator is resolved. As
+// expressions commonly contain separators, the argument may be single-quoted, such as match('^a|b$').
+func MatchFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	re, err := isMatchAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return Match(re), nil
 }
 
 // MaxLengthFactory generates a Validator that requires the length of a string, array, slice, or map to be less than or equal to a specified length.
@@ -219,7 +526,17 @@ func NotEqualFactory(ctx ResolutionContext, name string, args []string) (Validat
 
 	v, _ := tryParseString(ctx.Type, args[0])
 
-	return NotEqual(v), nil
+	return notEqual(v, comparerOf(ctx.Type)), nil
+}
+
+// NotEqualFieldFactory generates a Validator that requires a value to not be equal to a referenced field.
+func NotEqualFieldFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isFieldCmpAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return notEqualField(args[0], comparerOf(ctx.Type)), nil
 }
 
 // NotNilFactory generates a Validator that requires the value to not be nil.
@@ -240,17 +557,145 @@ func NotZeroFactory(ctx ResolutionContext, name string, args []string) (Validato
 
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		if isZero(ctx.Value) {
-			return newErrorf("must not be \"%v\"", zeroValue(ctx.Value.Type())), nil
+			return newFieldErrorf(ctx, "notzero", nil, "must not be \"%v\"", zeroValue(ctx.Value.Type())), nil
 		}
 		return nil, nil
 	}), nil
 }
 
+// PastFactory generates a Validator that requires a time to be before now.
+func PastFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgTimeAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return Past(), nil
+}
+
+// PortFactory generates a Validator that requires a string or an integer to be a port number.
+func PortFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgNetworkAllowed(ctx.Type, name, args, isPortAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return Port(), nil
+}
+
+// RequiredIfFactory generates a Validator that requires a value to be present when a referenced field is equal to a specified value.
+func RequiredIfFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	v, err := isPresenceIfAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return RequiredIf(args[0], v), nil
+}
+
+// RequiredUnlessFactory generates a Validator that requires a value to be present unless a referenced field is equal to a specified value.
+func RequiredUnlessFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	v, err := isPresenceIfAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return RequiredUnless(args[0], v), nil
+}
+
+// RequiredWithFactory generates a Validator that requires a value to be present when any of the referenced fields are present.
+func RequiredWithFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isPresenceWithAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return RequiredWith(args...), nil
+}
+
+// RequiredWithoutFactory generates a Validator that requires a value to be present when any of the referenced fields are absent.
+func RequiredWithoutFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isPresenceWithAllowed(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return RequiredWithout(args...), nil
+}
+
 // StructFactory generates a Validator for a struct.
 func StructFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
 	return ctx.LookupValidator(ctx.Type)
 }
 
+// ULIDFactory generates a Validator that requires a string to be a ULID.
+func ULIDFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgStringFormatAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return ULID(), nil
+}
+
+// UniqueFactory generates a Validator that requires the items of a slice or array, or the values
+// of a map, to be distinct. With a field name, structs are distinct by that field.
+func UniqueFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isUniqueAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 1 {
+		return uniqueField(args[0]), nil
+	}
+
+	return Unique(), nil
+}
+
+// URIFactory generates a Validator that requires a string to be an absolute URI.
+func URIFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	err := isNoArgStringFormatAllowed(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return URI(), nil
+}
+
+// URLFactory generates a Validator that requires a string to be an absolute URL. The schemes
+// it may use are specified with arguments such as "scheme=https".
+func URLFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	schemes, err := urlSchemes(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return URL(schemes...), nil
+}
+
+// UUIDFactory generates a Validator that requires a string to be a UUID. The version it must
+// be is specified with an argument such as "v4".
+func UUIDFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	version, err := uuidVersion(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return UUID(version), nil
+}
+
+// WithinFactory generates a Validator that requires a time to be no further than a duration,
+// such as "72h", from now.
+func WithinFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
+	d, err := withinDuration(ctx.Type, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return Within(d), nil
+}
+
 // ZeroFactory generates a Validator that requires the zero value.
 func ZeroFactory(ctx ResolutionContext, name string, args []string) (Validator, error) {
 	if len(args) > 0 {
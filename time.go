@@ -0,0 +1,186 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	tTime     = reflect.TypeOf(time.Time{})
+	tDuration = reflect.TypeOf(time.Duration(0))
+)
+
+// relativeTime is a time relative to when validation is performed, such as "now" or "now+24h".
+type relativeTime struct {
+	raw    string
+	offset time.Duration
+}
+
+func (rt relativeTime) String() string {
+	return rt.raw
+}
+
+// parseTimeArg parses an RFC 3339 time or a time relative to now, such as "now", "now+24h", or
+// "now-30m".
+func parseTimeArg(arg string) (interface{}, error) {
+	if !strings.HasPrefix(arg, "now") {
+		return time.Parse(time.RFC3339, arg)
+	}
+
+	rt := relativeTime{raw: arg}
+	if offset := arg[len("now"):]; offset != "" {
+		if offset[0] != '+' && offset[0] != '-' {
+			return nil, fmt.Errorf("invalid relative time %q", arg)
+		}
+
+		d, err := time.ParseDuration(offset)
+		if err != nil {
+			return nil, err
+		}
+		rt.offset = d
+	}
+
+	return rt, nil
+}
+
+// argValue returns the value of an argument to compare against, resolving times relative to
+// now with the clock of the ctx.
+func argValue(ctx Context, arg interface{}) reflect.Value {
+	if rt, ok := arg.(relativeTime); ok {
+		return reflect.ValueOf(ctx.Options.now().Add(rt.offset))
+	}
+
+	return reflect.ValueOf(arg)
+}
+
+// describeTime renders a time argument for messages.
+func describeTime(arg interface{}) string {
+	if t, ok := arg.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+
+	return fmt.Sprint(arg)
+}
+
+// After requires a time to be after the other time.
+func After(other time.Time) Validator {
+	return after(other)
+}
+
+// Before requires a time to be before the other time.
+func Before(other time.Time) Validator {
+	return before(other)
+}
+
+// Future requires a time to be after now.
+func Future() Validator {
+	return timeValidator("future", nil, "must be in the future", func(ctx Context, t time.Time) bool {
+		return t.After(ctx.Options.now())
+	})
+}
+
+// Past requires a time to be before now.
+func Past() Validator {
+	return timeValidator("past", nil, "must be in the past", func(ctx Context, t time.Time) bool {
+		return t.Before(ctx.Options.now())
+	})
+}
+
+// Within requires a time to be no further than the duration from now, in either direction.
+func Within(d time.Duration) Validator {
+	return timeValidator("within", []interface{}{d}, fmt.Sprintf("must be within %v of now", d), func(ctx Context, t time.Time) bool {
+		diff := t.Sub(ctx.Options.now())
+		return diff >= -d && diff <= d
+	})
+}
+
+func after(other interface{}) Validator {
+	return timeValidator("after", []interface{}{other}, "must be after "+describeTime(other), func(ctx Context, t time.Time) bool {
+		return t.After(argValue(ctx, other).Interface().(time.Time))
+	})
+}
+
+func before(other interface{}) Validator {
+	return timeValidator("before", []interface{}{other}, "must be before "+describeTime(other), func(ctx Context, t time.Time) bool {
+		return t.Before(argValue(ctx, other).Interface().(time.Time))
+	})
+}
+
+// timeValidator requires a time to satisfy valid. A nil pointer fails.
+func timeValidator(name string, args []interface{}, msg string, valid func(Context, time.Time) bool) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		val := indirect(ctx.Value)
+		if val.Kind() == reflect.Ptr {
+			return newFieldError(ctx, name, args, msg), nil
+		}
+		if val.Type() != tTime {
+			return isTimeAllowed(val.Type(), name, nil), nil
+		}
+		if !val.CanInterface() {
+			return fmt.Errorf("cannot validate an unexported %s", val.Type()), nil
+		}
+
+		if !valid(ctx, val.Interface().(time.Time)) {
+			return newFieldError(ctx, name, args, msg), nil
+		}
+		return nil, nil
+	})
+}
+
+func isTimeAllowed(t reflect.Type, name string, args []string) error {
+	switch {
+	case t == tTime:
+		return nil
+	case t.Kind() == reflect.Ptr:
+		return isTimeAllowed(t.Elem(), name, args)
+	default:
+		return InvalidTagArgumentsError{Message: "only pointers to/or time.Times are supported", ValidatorName: name, Args: args}
+	}
+}
+
+func isTimeArgAllowed(t reflect.Type, name string, args []string) error {
+	if err := isTimeAllowed(t, name, args); err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return InvalidTagArgumentsError{Message: "1 argument is required", ValidatorName: name, Args: args}
+	}
+
+	if _, err := parseTimeArg(args[0]); err != nil {
+		return InvalidTagArgumentsError{Message: "argument must be an RFC 3339 time or relative to now, such as now+24h", ValidatorName: name, Args: args}
+	}
+
+	return nil
+}
+
+func isNoArgTimeAllowed(t reflect.Type, name string, args []string) error {
+	if err := isTimeAllowed(t, name, args); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		return InvalidTagArgumentsError{Message: "no arguments are allowed", ValidatorName: name, Args: args}
+	}
+
+	return nil
+}
+
+func withinDuration(t reflect.Type, name string, args []string) (time.Duration, error) {
+	if err := isTimeAllowed(t, name, args); err != nil {
+		return 0, err
+	}
+
+	if len(args) != 1 {
+		return 0, InvalidTagArgumentsError{Message: "1 argument is required", ValidatorName: name, Args: args}
+	}
+
+	d, err := time.ParseDuration(args[0])
+	if err != nil || d < 0 {
+		return 0, InvalidTagArgumentsError{Message: "argument must be a non-negative duration, such as 72h", ValidatorName: name, Args: args}
+	}
+
+	return d, nil
+}
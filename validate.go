@@ -1,41 +1,67 @@
 package validate
 
 import (
+	"context"
 	"reflect"
 )
 
 // Validate performs validation on the obj and returns an error and a warning if it existed, respectively.
 //
 // Example:
-//   if err := validate.Validate(MyObject{}); err != nil {
-//   	// handle validation error
-//   }
+//
+//	if err := validate.Validate(MyObject{}); err != nil {
+//		// handle validation error
+//	}
 func Validate(obj interface{}, options ...Option) (error, error) {
+	return ValidateContext(context.Background(), obj, options...)
+}
+
+// ValidateContext is the same as Validate(), but validators can access the ctx through
+// Context.Context(). Validation stops with a CanceledError once the ctx is done.
+func ValidateContext(ctx context.Context, obj interface{}, options ...Option) (error, error) {
+	r, err := ValidateResult(ctx, obj, options...)
+	if err != nil {
+		return err, nil
+	}
+
+	return r.Err(), r.Warning()
+}
+
+// ValidateResult performs validation on the obj and returns a Result holding every issue with
+// its severity. An error is returned when validation cannot be performed, such as when the
+// struct tags are invalid or the ctx is done.
+func ValidateResult(ctx context.Context, obj interface{}, options ...Option) (*Result, error) {
 	opts := defaultOptions()
 	for _, option := range options {
 		option(opts)
 	}
 
+	opts.fieldMask = parseFieldMask(opts.FieldMask)
+	opts.excludeFields = parseFieldMask(opts.ExcludeFields)
+
 	rval := reflect.ValueOf(obj)
 
 	validator := opts.Validator
 	if validator == nil {
 		var err error
-		validator, err = opts.Registry.LookupValidator(rval.Type())
+		validator, err = opts.Registry.lookupValidatorInGroups(rval.Type(), opts.Groups)
 		if err != nil {
-			return err, nil
+			return nil, err
 		}
 	}
 
-	ctx := Context{
+	vctx := Context{
 		Options: opts,
 		Value:   rval,
+		ctx:     ctx,
 	}
 
-	if ctx.Options.WarningsAsErrors {
-		return mergeWarning(validator.Validate(ctx)), nil
+	err, warning := validator.Validate(vctx)
+	if isCanceled(err) {
+		return nil, err
 	}
-	return validator.Validate(ctx)
+
+	return newResult(err, warning, opts.failureThreshold()), nil
 }
 
 // ValidateWarningsAsErrors is the same as Validate(), but returns warnings as
@@ -1,8 +1,17 @@
 package validate_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/craiggwilson/validate"
 )
@@ -45,6 +54,101 @@ func TestValidate_And(t *testing.T) {
 	})
 }
 
+type color int
+
+const (
+	red color = iota + 1
+	green
+	blue
+)
+
+func (c color) String() string {
+	switch c {
+	case red:
+		return "Red"
+	case green:
+		return "Green"
+	case blue:
+		return "Blue"
+	default:
+		return fmt.Sprintf("color(%d)", int(c))
+	}
+}
+
+func (c color) Values() []color {
+	return []color{red, green, blue}
+}
+
+type size string
+
+func TestValidate_Enum(t *testing.T) {
+	type shirt struct {
+		Color  color  `validate:"enum"`
+		Accent *color `validate:"enum(Red, Blue)"`
+		Size   size   `validate:"enum"`
+	}
+
+	registry := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+		RegisterEnum(reflect.TypeOf(size("")), "S", "M", "L").
+		Build()
+
+	valid, invalid := red, green
+	for _, tc := range []struct {
+		name     string
+		instance shirt
+		err      string
+	}{
+		{"enum success", shirt{Color: blue, Accent: &valid, Size: "M"}, ""},
+		{"enum fail", shirt{Color: 7, Accent: &invalid, Size: "XL"}, `"Color" must be one of [Red Green Blue] and "Accent" must be one of [Red Blue] and "Size" must be one of [S M L]`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err, _ := validate.Validate(tc.instance, validate.WithRegistry(registry))
+			if err == nil && tc.err != "" {
+				t.Fatalf("expected error %v, but got none", tc.err)
+			} else if err != nil && err.Error() != tc.err {
+				t.Fatalf("expected error %q, but got %v", tc.err, err)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name     string
+		instance interface{}
+	}{
+		{"no values", struct {
+			Size size `validate:"enum"`
+		}{}},
+		{"unknown name", struct {
+			Color color `validate:"enum(Purple)"`
+		}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(tc.instance))
+			if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+				t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		value interface{}
+		panic string
+	}{
+		{"nil value", nil, "validate: nil enum value for validate_test.size"},
+		{"inconvertible value", []string{"S"}, `validate: enum value []string{"S"} of type []string cannot be converted to validate_test.size`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tc.panic {
+					t.Fatalf("expected panic %q, but got %v", tc.panic, r)
+				}
+			}()
+			validate.NewRegistryBuilder().RegisterEnum(reflect.TypeOf(size("")), "S", tc.value)
+		})
+	}
+}
+
 func TestValidate_Equals(t *testing.T) {
 	runTestCases(t, []testCase{
 		{
@@ -68,6 +172,500 @@ func TestValidate_Equals(t *testing.T) {
 	})
 }
 
+func TestValidate_FieldErrors(t *testing.T) {
+	type inner struct {
+		Ages map[string]int `validate:"items" validateItems:"gt(3)"`
+	}
+	instance := struct {
+		Name  string  `validate:"len(3),empty"`
+		Inner []inner `validate:"items" validateItems:"struct"`
+	}{
+		Name:  "A",
+		Inner: []inner{{Ages: map[string]int{"uno": 1}}},
+	}
+
+	err, _ := validate.Validate(instance)
+	ve, ok := err.(*validate.ValidationErrors)
+	if !ok {
+		t.Fatalf("expected *validate.ValidationErrors, but got %T", err)
+	}
+
+	expected := `"Name" must be of length 3 and must be empty and "Inner" [0] "Ages" [uno] must be greater than 3`
+	if ve.Error() != expected {
+		t.Fatalf("expected error %q, but got %q", expected, ve.Error())
+	}
+
+	fes := ve.FieldErrors()
+	if len(fes) != 3 {
+		t.Fatalf("expected 3 field errors, but got %d", len(fes))
+	}
+	for i, e := range []struct {
+		path          string
+		validatorName string
+		value         interface{}
+	}{
+		{"Name", "len", "A"},
+		{"Name", "empty", "A"},
+		{"Inner[0].Ages[uno]", "gt", 1},
+	} {
+		if fes[i].Path.String() != e.path {
+			t.Errorf("expected path %q, but got %q", e.path, fes[i].Path)
+		}
+		if fes[i].ValidatorName != e.validatorName {
+			t.Errorf("expected validator %q, but got %q", e.validatorName, fes[i].ValidatorName)
+		}
+		if fes[i].Value != e.value {
+			t.Errorf("expected value %v, but got %v", e.value, fes[i].Value)
+		}
+	}
+}
+
+type fieldCmpInner struct {
+	Min   int `validate:"ltfield(Max)"`
+	Max   int `validate:"ltefield(.Limit)"`
+	Total int `validate:"eqfield($.Total)"`
+}
+
+type fieldCmpOuter struct {
+	Limit int
+	Total int
+	Inner *fieldCmpInner `validate:"struct"`
+}
+
+func TestValidate_FieldComparison(t *testing.T) {
+	type dates struct {
+		Start int
+		End   int `validate:"gtfield(Start)"`
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"sibling (pass)",
+			struct {
+				Password        string
+				ConfirmPassword *string `validate:"eqfield(Password)"`
+			}{
+				Password:        "secret",
+				ConfirmPassword: stringPtr("secret"),
+			},
+			nil,
+		},
+		{
+			"sibling (fail)",
+			struct {
+				Password        string
+				ConfirmPassword *string `validate:"eqfield(Password)"`
+			}{
+				Password:        "secret",
+				ConfirmPassword: stringPtr("secrets"),
+			},
+			errors.New(`"ConfirmPassword" must be equal to field "Password"`),
+		},
+		{
+			"nil",
+			struct {
+				Password        string
+				ConfirmPassword *string `validate:"nefield(Password)"`
+			}{},
+			errors.New(`"ConfirmPassword" must not be equal to field "Password"`),
+		},
+		{
+			"mixed numbers",
+			struct {
+				Min uint8
+				Max float64 `validate:"gtefield(Min)"`
+			}{
+				Min: 3,
+				Max: 2.5,
+			},
+			errors.New(`"Max" must be greater than or equal to field "Min"`),
+		},
+		{
+			"items",
+			struct {
+				Dates []dates `validate:"items" validateItems:"struct"`
+			}{
+				Dates: []dates{{Start: 1, End: 2}, {Start: 2, End: 1}},
+			},
+			errors.New(`"Dates" [1] "End" must be greater than field "Start"`),
+		},
+		{
+			"enclosing and outermost (pass)",
+			fieldCmpOuter{
+				Limit: 5,
+				Total: 9,
+				Inner: &fieldCmpInner{Min: 1, Max: 5, Total: 9},
+			},
+			nil,
+		},
+		{
+			"enclosing and outermost (fail)",
+			fieldCmpOuter{
+				Limit: 5,
+				Total: 9,
+				Inner: &fieldCmpInner{Min: 6, Max: 6, Total: 8},
+			},
+			errors.New(`"Inner" "Min" must be less than field "Max" and "Max" must be less than or equal to field ".Limit" and "Total" must be equal to field "$.Total"`),
+		},
+		{
+			"unknown field",
+			struct {
+				ConfirmPassword string `validate:"eqfield(Passwrd)"`
+			}{},
+			errors.New(`"struct { ConfirmPassword string \"validate:\\\"eqfield(Passwrd)\\\"\" }" does not contain a field "Passwrd"`),
+		},
+		{
+			"incomparable",
+			struct {
+				Password        int
+				ConfirmPassword string `validate:"eqfield(Password)"`
+			}{},
+			errors.New(`(eqfield) the referenced field must be of a comparable type`),
+		},
+	})
+}
+
+func TestValidate_Conditional(t *testing.T) {
+	type address struct {
+		City string
+	}
+	type order struct {
+		DeliveryMethod  string
+		ShippingAddress *address `validate:"required_if(DeliveryMethod,ship)"`
+		Pickup          string   `validate:"excluded_if(DeliveryMethod, ship)"`
+		Quantity        int
+		Note            string `validate:"required_unless(Quantity,1)"`
+	}
+	type credentials struct {
+		Username string
+		Password string `validate:"required_with(Username)"`
+		Token    string `validate:"excluded_with(Username, Password)"`
+		Email    string `validate:"required_without(Username,Token)"`
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"required_if (pass)",
+			order{DeliveryMethod: "ship", ShippingAddress: &address{}, Quantity: 1},
+			nil,
+		},
+		{
+			"required_if (fail)",
+			order{DeliveryMethod: "ship", Quantity: 1},
+			errors.New(`"ShippingAddress" is required when field "DeliveryMethod" is ship`),
+		},
+		{
+			"excluded_if and required_unless (fail)",
+			order{DeliveryMethod: "ship", ShippingAddress: &address{}, Pickup: "store", Quantity: 2},
+			errors.New(`"Pickup" must be absent when field "DeliveryMethod" is ship and "Note" is required unless field "Quantity" is 1`),
+		},
+		{
+			"required_with (fail)",
+			credentials{Username: "user", Token: "abc"},
+			errors.New(`"Password" is required when field "Username" is present and "Token" must be absent when field "Username" is present`),
+		},
+		{
+			"excluded_with and required_without (pass)",
+			credentials{Token: "abc", Email: "user@example.com"},
+			nil,
+		},
+		{
+			"required_without (fail)",
+			credentials{},
+			errors.New(`"Email" is required when field "Username" is absent`),
+		},
+		{
+			"unknown field",
+			struct {
+				Token string `validate:"excluded_with(Username)"`
+			}{},
+			errors.New(`"struct { Token string \"validate:\\\"excluded_with(Username)\\\"\" }" does not contain a field "Username"`),
+		},
+		{
+			"invalid value",
+			struct {
+				Quantity int
+				Note     string `validate:"required_if(Quantity,one)"`
+			}{},
+			errors.New(`(required_if) argument must be an integer`),
+		},
+	})
+}
+
+func TestValidate_Groups(t *testing.T) {
+	type address struct {
+		City string `validate:"update:notempty"`
+	}
+	type user struct {
+		ID      int      `validate:"create:zero; update:notzero"`
+		Name    string   `validate:"notempty; create,update:maxlen(5)"`
+		Address *address `validate:"struct"`
+	}
+
+	for _, tc := range []struct {
+		name     string
+		instance user
+		groups   []string
+		err      string
+	}{
+		{"no groups", user{ID: 3, Name: "Rumpelstiltskin", Address: &address{}}, nil, ""},
+		{"no groups fail", user{ID: 3}, nil, `"Name" must not be empty`},
+		{"create", user{Name: "Bob", Address: &address{}}, []string{"create"}, ""},
+		{"create fail", user{ID: 3, Name: "Rumpelstiltskin"}, []string{"create"}, `"ID" must be "0" and "Name" must have max length 5`},
+		{"update", user{ID: 3, Name: "Bob", Address: &address{City: "Austin"}}, []string{"update"}, ""},
+		{"update fail", user{Name: "Bob", Address: &address{}}, []string{"update"}, `"ID" must not be "0" and "Address" "City" must not be empty`},
+		{"create and update fail", user{Name: "Bob", Address: &address{}}, []string{"update", "create"}, `"ID" must not be "0" and "Address" "City" must not be empty`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err, _ := validate.Validate(tc.instance, validate.WithGroups(tc.groups...))
+			if err == nil && tc.err != "" {
+				t.Fatalf("expected error %v, but got none", tc.err)
+			} else if err != nil && err.Error() != tc.err {
+				t.Fatalf("expected error %q, but got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+type maskedNode struct {
+	Name     string        `validate:"notempty"`
+	Children []*maskedNode `validate:"items" validateItems:"struct"`
+}
+
+func TestValidate_FieldMask(t *testing.T) {
+	type address struct {
+		Street string `validate:"notempty"`
+		City   string `validate:"notempty"`
+	}
+	type user struct {
+		Name    string            `validate:"notempty"`
+		Address address           `validate:"struct"`
+		Tags    []string          `validate:"items" validateItems:"notempty"`
+		Phones  map[string]string `validate:"items" validateItems:"notempty"`
+		Tree    maskedNode        `validate:"struct"`
+	}
+
+	instance := user{
+		Tags:   []string{"a", ""},
+		Phones: map[string]string{"home": ""},
+		Tree: maskedNode{
+			Children: []*maskedNode{{Name: "a"}, {}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		options []validate.Option
+		err     string
+	}{
+		{"empty mask", []validate.Option{validate.WithFieldMask([]string{})}, ""},
+		{"nested field", []validate.Option{validate.WithFieldMask([]string{"Address.City"})}, `"Address" "City" must not be empty`},
+		{"items", []validate.Option{validate.WithFieldMask([]string{"Tags"})}, `"Tags" [1] must not be empty`},
+		{"index", []validate.Option{validate.WithFieldMask([]string{"Tags[0]"})}, ""},
+		{"key", []validate.Option{validate.WithFieldMask([]string{"Phones.home"})}, `"Phones" [home] must not be empty`},
+		{"recursive wildcard", []validate.Option{validate.WithFieldMask([]string{"Tree.Children.*.Name"})}, `"Tree" "Children" [1] "Name" must not be empty`},
+		{"exclude", []validate.Option{validate.WithExcludeFields([]string{"Name", "Address.Street", "Tags", "Phones.*", "Tree"})}, `"Address" "City" must not be empty`},
+		{"mask and exclude", []validate.Option{validate.WithFieldMask([]string{"Address"}), validate.WithExcludeFields([]string{"Address.City"})}, `"Address" "Street" must not be empty`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err, _ := validate.Validate(instance, tc.options...)
+			if err == nil && tc.err != "" {
+				t.Fatalf("expected error %v, but got none", tc.err)
+			} else if err != nil && err.Error() != tc.err {
+				t.Fatalf("expected error %q, but got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestValidate_Formats(t *testing.T) {
+	type formats struct {
+		Email    string  `validate:"email"`
+		Website  *string `validate:"url(scheme=https)"`
+		Callback string  `validate:"uri"`
+		Host     string  `validate:"hostname"`
+		Domain   string  `validate:"fqdn"`
+		ID       string  `validate:"uuid(v4)"`
+		Event    string  `validate:"ulid"`
+	}
+
+	valid := formats{
+		Email:    "gopher@example.com",
+		Website:  stringPtr("https://example.com/docs"),
+		Callback: "urn:isbn:0451450523",
+		Host:     "db-1",
+		Domain:   "api.example.com.",
+		ID:       "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		Event:    "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"formats success",
+			valid,
+			nil,
+		},
+		{
+			"formats fail",
+			formats{
+				Email:    "Gopher <gopher@example.com>",
+				Website:  stringPtr("http://example.com"),
+				Callback: "/relative",
+				Host:     "-db",
+				Domain:   "localhost",
+				ID:       "f47ac10b-58cc-1372-a567-0e02b2c3d479",
+				Event:    "01ARZ3NDEKTSV4RRFFQ69G5FAI",
+			},
+			errors.New(`"Email" must be a valid email address and "Website" must be a valid URL with scheme https and ` +
+				`"Callback" must be a valid URI and "Host" must be a valid hostname and "Domain" must be a fully qualified domain name and ` +
+				`"ID" must be a valid version 4 UUID and "Event" must be a valid ULID`),
+		},
+	})
+
+	for _, tc := range []struct {
+		name     string
+		instance interface{}
+	}{
+		{"not a string", struct {
+			N int `validate:"email"`
+		}{}},
+		{"bad scheme argument", struct {
+			S string `validate:"url(https)"`
+		}{}},
+		{"bad uuid version", struct {
+			S string `validate:"uuid(v9)"`
+		}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(tc.instance))
+			if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+				t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+			}
+		})
+	}
+}
+
+type money struct {
+	cents int64
+}
+
+func (m money) Compare(other money) int {
+	switch {
+	case m.cents < other.cents:
+		return -1
+	case m.cents > other.cents:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m *money) ParseValidateArg(arg string) (money, error) {
+	f, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return money{}, err
+	}
+
+	return money{cents: int64(f * 100)}, nil
+}
+
+func (m money) String() string {
+	return fmt.Sprintf("%d.%02d", m.cents/100, m.cents%100)
+}
+
+type version string
+
+func (v version) Compare(other version) int {
+	a, b := strings.Split(string(v), "."), strings.Split(string(other), ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, _ := strconv.Atoi(a[i])
+		y, _ := strconv.Atoi(b[i])
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return len(a) - len(b)
+}
+
+func TestValidate_Compare(t *testing.T) {
+	type order struct {
+		Total    money   `validate:"gt(0),lte(100.00)"`
+		Discount *money  `validate:"in(0, 5.00, 10.00)"`
+		Client   version `validate:"gte(1.9)"`
+		Max      version `validate:"gtefield(Client)"`
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"compare success",
+			order{Total: money{cents: 1250}, Discount: &money{cents: 500}, Client: "1.10", Max: "2.0"},
+			nil,
+		},
+		{
+			"compare fail",
+			order{Total: money{cents: 10001}, Discount: &money{cents: 250}, Client: "1.8", Max: "1.7.9"},
+			errors.New(`"Total" must be less than or equal to 100.00 and "Discount" must be one of [0.00 5.00 10.00] and ` +
+				`"Client" must be greater than or equal to 1.9 and "Max" must be greater than or equal to field "Client"`),
+		},
+	})
+
+	_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(struct {
+		Total    money  `validate:"gt(0)"`
+		Discount *money `validate:"ltefield(Total)"`
+	}{}))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		instance interface{}
+	}{
+		{"bad argument", struct {
+			Total money `validate:"gt(lots)"`
+		}{}},
+		{"unrelated field", struct {
+			Total  money
+			Client version `validate:"gtfield(Total)"`
+		}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(tc.instance))
+			if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+				t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+			}
+		})
+	}
+}
+
+func TestValidate_CompareFloats(t *testing.T) {
+	type reading struct {
+		Value float64 `validate:"gt(0),lte(99.5)"`
+		Min   int
+		Max   uint
+		Low   float32 `validate:"gtefield(Min),ltfield(Value)"`
+		High  float64 `validate:"ltefield(Max),nefield(Low)"`
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"pass",
+			reading{Value: 99.5, Min: -2, Max: 3, Low: -1.5, High: 2.5},
+			nil,
+		},
+		{
+			"fail",
+			reading{Value: 99.75, Min: 2, Max: 3, Low: 1.5, High: 3.25},
+			errors.New(`"Value" must be less than or equal to 99.5 and "Low" must be greater than or equal to field "Min" and ` +
+				`"High" must be less than or equal to field "Max"`),
+		},
+	})
+}
+
 func TestValidate_GreaterThan(t *testing.T) {
 	runTestCases(t, []testCase{
 		{
@@ -114,6 +712,78 @@ func TestValidate_GreaterThanOrEqual(t *testing.T) {
 	})
 }
 
+func TestValidate_Time(t *testing.T) {
+	type booking struct {
+		Start    time.Time     `validate:"gte(2020-01-01T00:00:00Z),future"`
+		End      *time.Time    `validate:"lt(now+720h),gtfield(Start)"`
+		Created  time.Time     `validate:"past,within(72h)"`
+		Expires  time.Time     `validate:"after(now+24h),before(2030-01-01T00:00:00Z)"`
+		Duration time.Duration `validate:"gte(30m),lte(8h)"`
+	}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := validate.WithClock(func() time.Time { return now })
+	hours := func(h int) time.Time { return now.Add(time.Duration(h) * time.Hour) }
+	timePtr := func(t time.Time) *time.Time { return &t }
+
+	err, _ := validate.Validate(booking{
+		Start:    hours(1),
+		End:      timePtr(hours(3)),
+		Created:  hours(-1),
+		Expires:  hours(48),
+		Duration: 2 * time.Hour,
+	}, clock)
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	err, _ = validate.Validate(booking{
+		Start:    hours(-1),
+		End:      timePtr(hours(-2)),
+		Created:  hours(-100),
+		Expires:  hours(12),
+		Duration: 10 * time.Second,
+	}, clock)
+	expected := `"Start" must be in the future and "End" must be greater than field "Start" and "Created" must be within 72h0m0s of now and ` +
+		`"Expires" must be after now+24h and "Duration" must be greater than or equal to 30m0s`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, but got %v", expected, err)
+	}
+
+	err, _ = validate.Validate(struct {
+		Timeout time.Duration `validate:"gt(1000000000)"`
+	}{Timeout: time.Second})
+	expected = `"Timeout" must be greater than 1s`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, but got %v", expected, err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		instance interface{}
+	}{
+		{"bad time", struct {
+			T time.Time `validate:"gt(tomorrow)"`
+		}{}},
+		{"bad relative time", struct {
+			T time.Time `validate:"before(now*2)"`
+		}{}},
+		{"bad duration", struct {
+			D time.Duration `validate:"lt(5 fortnights)"`
+		}{}},
+		{"not a time", struct {
+			S string `validate:"future"`
+		}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(tc.instance))
+			if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+				t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+			}
+		})
+	}
+}
+
 func TestValidate_In(t *testing.T) {
 	runTestCases(t, []testCase{
 		{
@@ -134,6 +804,42 @@ func TestValidate_In(t *testing.T) {
 			},
 			errors.New(`"Age" must be one of [1 2 3]`),
 		},
+		{
+			"whitespace around arguments",
+			struct {
+				Age int `validate:" in( 1, 2 , 3 ) "`
+			}{
+				Age: 3,
+			},
+			nil,
+		},
+		{
+			"quoted arguments (pass)",
+			struct {
+				Name string `validate:"in(\"a,b\", 'c|d', \"e)~f\")"`
+			}{
+				Name: "c|d",
+			},
+			nil,
+		},
+		{
+			"quoted arguments (fail)",
+			struct {
+				Name string `validate:"in(\"a,b\", 'c|d', ' e)~f ', 'g\\'h', \"i\\\\\")|empty~must be a letter"`
+			}{
+				Name: "x",
+			},
+			errors.New(`must be a letter`),
+		},
+		{
+			"quoted arguments with escapes",
+			struct {
+				Name string `validate:"in(' e)~f ', 'g\\'h', \"i\\\\\")"`
+			}{
+				Name: "x",
+			},
+			errors.New(`"Name" must be one of [ e)~f  g'h i\]`),
+		},
 	})
 }
 
@@ -223,98 +929,345 @@ func TestValidate_Items(t *testing.T) {
 	})
 }
 
+func TestValidate_Dive(t *testing.T) {
+	type matrix struct {
+		Rows [][]string `validate:"minlen(1),dive,minlen(2),dive,notempty"`
+	}
+	type scores struct {
+		Scores *map[string][]int `validate:"dive,notempty,dive,gt(0)"`
+	}
+	type tags struct {
+		Tags []string `validate:"notempty,dive,minlen(3)|empty"`
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"dive success",
+			matrix{Rows: [][]string{{"a", "b"}, {"c", "d"}}},
+			nil,
+		},
+		{
+			"dive fail collection",
+			matrix{},
+			errors.New(`"Rows" must have min length 1`),
+		},
+		{
+			"dive fail items",
+			matrix{Rows: [][]string{{"a"}, {"c", ""}}},
+			errors.New(`"Rows" [0] must have min length 2 and [1] [1] must not be empty`),
+		},
+		{
+			"dive map success",
+			scores{Scores: &map[string][]int{"a": {1, 2}}},
+			nil,
+		},
+		{
+			"dive map fail",
+			scores{Scores: &map[string][]int{"a": {1, 0}}},
+			errors.New(`"Scores" [a] [1] must be greater than 0`),
+		},
+		{
+			"dive disjunction success",
+			tags{Tags: []string{"abc", ""}},
+			nil,
+		},
+		{
+			"dive disjunction fail",
+			tags{Tags: []string{"abc", "ab"}},
+			errors.New(`"Tags" [1] must have min length 3 or must be empty`),
+		},
+	})
+
+	_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(struct {
+		Name string `validate:"dive,notempty"`
+	}{}))
+	if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+		t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+	}
+}
+
+func TestValidate_Keys(t *testing.T) {
+	type labels struct {
+		Labels map[string]string `validate:"keys,items" validateKeys:"minlen(1),match(^[a-z_]+$)" validateItems:"notempty"`
+	}
+	type counts struct {
+		Counts *map[int]int `validate:"keys(k)" k:"gt(0)"`
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"keys success",
+			labels{Labels: map[string]string{"app_name": "validate"}},
+			nil,
+		},
+		{
+			"keys fail",
+			labels{Labels: map[string]string{"App": "validate"}},
+			errors.New(`"Labels" [App] must match "^[a-z_]+$"`),
+		},
+		{
+			"keys and items fail",
+			labels{Labels: map[string]string{"": ""}},
+			errors.New(`"Labels" [] must have min length 1 and must match "^[a-z_]+$" and [] must not be empty`),
+		},
+		{
+			"keys custom tag name fail",
+			counts{Counts: &map[int]int{0: 1}},
+			errors.New(`"Counts" [0] must be greater than 0`),
+		},
+	})
+
+	err, _ := validate.Validate(labels{Labels: map[string]string{"": ""}})
+	fes := err.(*validate.ValidationErrors).FieldErrors()
+	kinds := []validate.PathSegmentKind{validate.MapKeySegment, validate.MapKeySegment, validate.KeySegment}
+	if len(fes) != len(kinds) {
+		t.Fatalf("expected %d field errors, but got %d", len(kinds), len(fes))
+	}
+	for i, kind := range kinds {
+		if seg := fes[i].Path[1]; seg.Kind != kind || seg.Key != "" {
+			t.Errorf("expected field error %d to be at a segment of kind %d, but got %+v", i, kind, seg)
+		}
+	}
+
+	_, err = validate.DefaultRegistry.LookupValidator(reflect.TypeOf(struct {
+		Names []string `validate:"keys" validateKeys:"notempty"`
+	}{}))
+	if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+		t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+	}
+}
+
 func TestValidate_Length(t *testing.T) {
 	runTestCases(t, []testCase{
 		{
-			"string of length 3 (pass)",
+			"string of length 3 (pass)",
+			struct {
+				Name string `validate:"len(3)"`
+			}{
+				Name: "ABC",
+			},
+			nil,
+		},
+		{
+			"string of length 3 (fail)",
+			struct {
+				Name string `validate:"len(3)"`
+			}{
+				Name: "A",
+			},
+			errors.New(`"Name" must be of length 3`),
+		},
+		{
+			"string ptr of length 3 (pass)",
+			struct {
+				Name *string `validate:"len(3)"`
+			}{
+				Name: stringPtr("ABC"),
+			},
+			nil,
+		},
+		{
+			"string ptr of length 3 (fail)",
+			struct {
+				Name *string `validate:"len(3)"`
+			}{
+				Name: stringPtr("A"),
+			},
+			errors.New(`"Name" must be of length 3`),
+		},
+		{
+			"nil string ptr of length 3 (fail)",
+			struct {
+				Name *string `validate:"len(3)"`
+			}{},
+			errors.New(`"Name" must be of length 3`),
+		},
+	})
+}
+
+func TestValidate_LessThan(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			"pass",
+			struct {
+				Age int `validate:"lt(3)"`
+			}{
+				Age: 2,
+			},
+			nil,
+		},
+		{
+			"fail",
+			struct {
+				Age int `validate:"lt(3)"`
+			}{
+				Age: 3,
+			},
+			errors.New(`"Age" must be less than 3`),
+		},
+	})
+}
+
+func TestValidate_LessThanOrEqual(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			"pass",
+			struct {
+				Age int `validate:"lte(3)"`
+			}{
+				Age: 3,
+			},
+			nil,
+		},
+		{
+			"fail",
+			struct {
+				Age int `validate:"lte(3)"`
+			}{
+				Age: 4,
+			},
+			errors.New(`"Age" must be less than or equal to 3`),
+		},
+	})
+}
+
+func TestValidate_Match(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			"pass",
 			struct {
-				Name string `validate:"len(3)"`
+				Name string `validate:"match(^[a-z]+$)"`
 			}{
-				Name: "ABC",
+				Name: "abc",
 			},
 			nil,
 		},
 		{
-			"string of length 3 (fail)",
+			"fail",
 			struct {
-				Name string `validate:"len(3)"`
+				Name string `validate:"match(^[a-z]+$)"`
 			}{
-				Name: "A",
+				Name: "ABC",
 			},
-			errors.New(`"Name" must be of length 3`),
+			errors.New(`"Name" must match "^[a-z]+$"`),
 		},
 		{
-			"string ptr of length 3 (pass)",
+			"quoted with separators (pass)",
 			struct {
-				Name *string `validate:"len(3)"`
+				Name *string `validate:"regex('^(a|b),(c|d)$')|empty"`
 			}{
-				Name: stringPtr("ABC"),
+				Name: stringPtr("b,c"),
 			},
 			nil,
 		},
 		{
-			"string ptr of length 3 (fail)",
+			"quoted with separators (fail)",
 			struct {
-				Name *string `validate:"len(3)"`
+				Name *string `validate:"regex('^(a|b),(c|d)$')"`
 			}{
-				Name: stringPtr("A"),
+				Name: stringPtr("c,c"),
 			},
-			errors.New(`"Name" must be of length 3`),
+			errors.New(`"Name" must match "^(a|b),(c|d)$"`),
 		},
 		{
-			"nil string ptr of length 3 (fail)",
+			"quoted with escapes",
 			struct {
-				Name *string `validate:"len(3)"`
+				Name string `validate:"match('^\\d+\\'$')~digits and a quote"`
+			}{
+				Name: "12",
+			},
+			errors.New(`digits and a quote`),
+		},
+		{
+			"nil string ptr",
+			struct {
+				Name *string `validate:"match(a)"`
 			}{},
-			errors.New(`"Name" must be of length 3`),
+			errors.New(`"Name" must match "a"`),
 		},
-	})
-}
-
-func TestValidate_LessThan(t *testing.T) {
-	runTestCases(t, []testCase{
 		{
-			"pass",
+			"invalid pattern",
 			struct {
-				Age int `validate:"lt(3)"`
-			}{
-				Age: 2,
-			},
-			nil,
+				Name string `validate:"match('(')"`
+			}{},
+			errors.New("(match) argument must be a valid regular expression: error parsing regexp: missing closing ): `(`"),
 		},
 		{
-			"fail",
+			"int",
 			struct {
-				Age int `validate:"lt(3)"`
-			}{
-				Age: 3,
-			},
-			errors.New(`"Age" must be less than 3`),
+				Age int `validate:"match(a)"`
+			}{},
+			errors.New("(match) only pointers to/or strings are supported"),
 		},
 	})
 }
 
-func TestValidate_LessThanOrEqual(t *testing.T) {
+func TestValidate_Network(t *testing.T) {
+	type addresses struct {
+		Any      string           `validate:"ip"`
+		V4       net.IP           `validate:"ipv4"`
+		V6       *string          `validate:"ipv6"`
+		Subnet   *net.IPNet       `validate:"cidr,cidr_contains(10.0.0.0/8)"`
+		Gateway  net.IP           `validate:"cidr_contains(10.0.0.0/8)"`
+		Listen   string           `validate:"hostport"`
+		Port     int              `validate:"port"`
+		Hardware net.HardwareAddr `validate:"mac"`
+	}
+
+	_, subnet, _ := net.ParseCIDR("10.1.0.0/16")
+	_, outside, _ := net.ParseCIDR("192.168.0.0/16")
+	hardware, _ := net.ParseMAC("00:00:5e:00:53:01")
+
 	runTestCases(t, []testCase{
 		{
-			"pass",
-			struct {
-				Age int `validate:"lte(3)"`
-			}{
-				Age: 3,
+			"network success",
+			addresses{
+				Any:      "2001:db8::1",
+				V4:       net.ParseIP("192.0.2.1"),
+				V6:       stringPtr("::1"),
+				Subnet:   subnet,
+				Gateway:  net.ParseIP("10.1.0.1"),
+				Listen:   "example.com:443",
+				Port:     8080,
+				Hardware: hardware,
 			},
 			nil,
 		},
 		{
-			"fail",
-			struct {
-				Age int `validate:"lte(3)"`
-			}{
-				Age: 4,
+			"network fail",
+			addresses{
+				Any:     "localhost",
+				V4:      net.ParseIP("2001:db8::1"),
+				V6:      stringPtr("192.0.2.1"),
+				Subnet:  outside,
+				Gateway: net.ParseIP("192.168.0.1"),
+				Listen:  "example.com",
+				Port:    70000,
 			},
-			errors.New(`"Age" must be less than or equal to 3`),
+			errors.New(`"Any" must be a valid IP address and "V4" must be a valid IPv4 address and "V6" must be a valid IPv6 address and ` +
+				`"Subnet" must be within 10.0.0.0/8 and "Gateway" must be within 10.0.0.0/8 and "Listen" must be a valid host and port and ` +
+				`"Port" must be a valid port and "Hardware" must be a valid MAC address`),
 		},
 	})
+
+	for _, tc := range []struct {
+		name     string
+		instance interface{}
+	}{
+		{"unsupported type", struct {
+			IP net.IPNet `validate:"ip"`
+		}{}},
+		{"invalid block", struct {
+			IP string `validate:"cidr_contains(10.0.0.0)"`
+		}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(tc.instance))
+			if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+				t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+			}
+		})
+	}
 }
 
 func TestValidate_NotEmpty(t *testing.T) {
@@ -453,6 +1406,236 @@ func TestValidate_Or(t *testing.T) {
 	})
 }
 
+func TestValidate_TagSyntaxError(t *testing.T) {
+	type unterminatedArgs struct {
+		Name string `validate:"len(3"`
+	}
+	type emptyName struct {
+		Name string `validate:"len(3),|empty"`
+	}
+	type trailing struct {
+		Name string `validate:"len(3) x"`
+	}
+	type unterminatedQuote struct {
+		Name string `validate:"in('a)"`
+	}
+	type unbalanced struct {
+		Name string `validate:"match(^(a$"`
+	}
+	type items struct {
+		Names []string `validate:"items" validateItems:"notempty)"`
+	}
+
+	for _, tc := range []struct {
+		name     string
+		instance interface{}
+		err      string
+	}{
+		{"unterminated args", unterminatedArgs{}, `validate_test.unterminatedArgs.Name: invalid validate tag "len(3" at offset 5: expected "," or ")"`},
+		{"empty name", emptyName{}, `validate_test.emptyName.Name: invalid validate tag "len(3),|empty" at offset 7: expected validator name`},
+		{"trailing", trailing{}, `validate_test.trailing.Name: invalid validate tag "len(3) x" at offset 7: expected ",", "|", ";", or "~"`},
+		{"unterminated quote", unterminatedQuote{}, `validate_test.unterminatedQuote.Name: invalid validate tag "in('a)" at offset 6: expected closing '`},
+		{"unbalanced", unbalanced{}, `validate_test.unbalanced.Name: invalid validate tag "match(^(a$" at offset 10: expected ")"`},
+		{"items", items{}, `validate_test.items.Names: invalid validateItems tag "notempty)" at offset 8: expected ",", "|", ";", or "~"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validate.DefaultRegistry.LookupValidator(reflect.TypeOf(tc.instance))
+			if _, ok := err.(validate.TagSyntaxError); !ok {
+				t.Fatalf("expected validate.TagSyntaxError, but got %T", err)
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("expected error %v, but got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+type uniqueUser struct {
+	Name  string
+	Email string
+}
+
+func TestValidate_Unique(t *testing.T) {
+	type tags struct {
+		Tags []string `validate:"unique"`
+	}
+	type points struct {
+		Points [2][2]int `validate:"unique"`
+	}
+	type users struct {
+		Users []*uniqueUser `validate:"unique(Email)"`
+	}
+	type roles struct {
+		Roles map[string]int `validate:"unique"`
+	}
+	type holder struct {
+		X interface{}
+	}
+	type holders struct {
+		Items []holder `validate:"unique"`
+	}
+	type values struct {
+		Values []interface{} `validate:"unique"`
+	}
+
+	runTestCases(t, []testCase{
+		{
+			"unique success",
+			tags{Tags: []string{"a", "b", "c"}},
+			nil,
+		},
+		{
+			"unique fail",
+			tags{Tags: []string{"a", "b", "a", "b", "a"}},
+			errors.New(`"Tags" must have unique items, but [0] and [2] are equal and must have unique items, but [1] and [3] are equal and must have unique items, but [0] and [4] are equal`),
+		},
+		{
+			"unique composite fail",
+			points{Points: [2][2]int{{1, 2}, {1, 2}}},
+			errors.New(`"Points" must have unique items, but [0] and [1] are equal`),
+		},
+		{
+			"unique field success",
+			users{Users: []*uniqueUser{{"a", "a@example.com"}, {"a", "b@example.com"}}},
+			nil,
+		},
+		{
+			"unique field fail",
+			users{Users: []*uniqueUser{{"a", "a@example.com"}, {"b", "a@example.com"}}},
+			errors.New(`"Users" must have unique Email, but [0] and [1] are equal`),
+		},
+		{
+			"unique map values fail",
+			roles{Roles: map[string]int{"c": 1, "a": 1, "b": 2}},
+			errors.New(`"Roles" must have unique items, but [a] and [c] are equal`),
+		},
+		{
+			"unique unhashable fail",
+			holders{Items: []holder{{X: []int{1}}, {X: 1}, {X: []int{1}}}},
+			errors.New(`"Items" must have unique items, but [0] and [2] are equal`),
+		},
+		{
+			"unique interface success",
+			values{Values: []interface{}{1, int64(1), "1", []int{1}, nil}},
+			nil,
+		},
+		{
+			"unique interface fail",
+			values{Values: []interface{}{1, []int{2}, 1, []int{2}}},
+			errors.New(`"Values" must have unique items, but [0] and [2] are equal and must have unique items, but [1] and [3] are equal`),
+		},
+	})
+
+	err, _ := validate.Validate([]uniqueUser{{"a", "a@example.com"}, {"A", "b@example.com"}}, validate.WithValidator(validate.UniqueBy(func(v reflect.Value) interface{} {
+		return strings.ToLower(v.FieldByName("Name").String())
+	})))
+	if err == nil || err.Error() != `must have unique items, but [0] and [1] are equal` {
+		t.Fatalf("expected duplicate names, but got %v", err)
+	}
+
+	_, err = validate.DefaultRegistry.LookupValidator(reflect.TypeOf(struct {
+		Users []uniqueUser `validate:"unique(Phone)"`
+	}{}))
+	if _, ok := err.(validate.InvalidTagArgumentsError); !ok {
+		t.Fatalf("expected validate.InvalidTagArgumentsError, but got %T: %v", err, err)
+	}
+}
+
+type contextKey struct{}
+
+type cancelingValidator struct {
+	cancel func()
+}
+
+func (cv cancelingValidator) Validate(ctx validate.Context) (error, error) {
+	if ctx.Context().Value(contextKey{}) != "tenant" {
+		return errors.New("missing tenant"), nil
+	}
+
+	cv.cancel()
+	return nil, nil
+}
+
+func TestValidateContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey{}, "tenant"))
+	defer cancel()
+
+	instance := struct {
+		Names []string           `validate:"items" validateItems:"notempty"`
+		C     cancelingValidator `validate:"struct"`
+		Name  string             `validate:"notempty"`
+	}{
+		Names: []string{""},
+		C:     cancelingValidator{cancel: cancel},
+	}
+
+	err, _ := validate.ValidateContext(ctx, instance)
+	ce, ok := err.(validate.CanceledError)
+	if !ok {
+		t.Fatalf("expected validate.CanceledError, but got %T: %v", err, err)
+	}
+	if ce.Err != context.Canceled {
+		t.Fatalf("expected %v, but got %v", context.Canceled, ce.Err)
+	}
+
+	err = validate.ValidateWarningsAsErrors(instance)
+	if err == nil || err.Error() != `"Names" [0] must not be empty and "C" missing tenant and "Name" must not be empty` {
+		t.Fatalf("expected all errors without a context, but got %v", err)
+	}
+}
+
+func TestValidateResult(t *testing.T) {
+	type account struct {
+		ID    string `validate:"notempty!fatal"`
+		Name  string `validate:"maxlen(5)!warn"`
+		Bio   string `validate:"maxlen(10)!info"`
+		Email string `validate:"notempty"`
+	}
+
+	r, err := validate.ValidateResult(context.Background(), account{ID: "1", Name: "Rumpelstiltskin", Bio: "once upon a time"})
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+	if !r.Failed() {
+		t.Fatalf("expected failure")
+	}
+	if r.Err() == nil || r.Err().Error() != `"Email" must not be empty` {
+		t.Fatalf("expected only the error to fail, but got %v", r.Err())
+	}
+	if r.Warning() == nil || r.Warning().Error() != `"Name" must have max length 5 and "Bio" must have max length 10` {
+		t.Fatalf("expected the warning and info to pass, but got %v", r.Warning())
+	}
+
+	var severities []validate.Severity
+	for _, issue := range r.Issues {
+		severities = append(severities, issue.Severity)
+	}
+	expected := []validate.Severity{validate.SeverityError, validate.SeverityWarning, validate.SeverityInfo}
+	if !reflect.DeepEqual(severities, expected) {
+		t.Fatalf("expected severities %v, but got %v", expected, severities)
+	}
+
+	r, _ = validate.ValidateResult(context.Background(), account{Name: "Rumpelstiltskin"})
+	if r.Err() == nil || r.Err().Error() != `"ID" must not be empty` {
+		t.Fatalf("expected a fatal issue to stop validation, but got %v", r.Err())
+	}
+
+	err, warning := validate.Validate(account{ID: "1", Name: "Rumpelstiltskin", Bio: "once upon a time", Email: "a@b.c"}, validate.WithFailureThreshold(validate.SeverityWarning))
+	if err == nil || err.Error() != `"Name" must have max length 5` {
+		t.Fatalf("expected the warning to fail, but got %v", err)
+	}
+	if warning == nil || warning.Error() != `"Bio" must have max length 10` {
+		t.Fatalf("expected the info to pass, but got %v", warning)
+	}
+
+	_, err = validate.ValidateResult(context.Background(), struct {
+		Name string `validate:"notempty!loud"`
+	}{})
+	if _, ok := err.(validate.TagSyntaxError); !ok {
+		t.Fatalf("expected validate.TagSyntaxError, but got %T: %v", err, err)
+	}
+}
+
 type selfValidator struct {
 	v int
 }
@@ -599,6 +1782,41 @@ func TestValidate_StructCycle(t *testing.T) {
 	})
 }
 
+type cycleNode struct {
+	Name     string       `validate:"notempty"`
+	Next     *cycleNode   `validate:"struct"`
+	Children []*cycleNode `validate:"items" validateItems:"struct"`
+}
+
+func TestValidate_StructCycle_Concurrent(t *testing.T) {
+	instance := &cycleNode{
+		Name: "a",
+		Next: &cycleNode{Name: "b", Next: &cycleNode{}},
+		Children: []*cycleNode{
+			{Name: "c", Children: []*cycleNode{{Name: "d", Next: &cycleNode{}}}},
+			{},
+		},
+	}
+	expected := `"Next" "Next" "Name" must not be empty and "Children" [0] "Children" [0] "Next" "Name" must not be empty and [1] "Name" must not be empty`
+
+	for run := 0; run < 20; run++ {
+		registry := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).Build()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err, _ := validate.Validate(instance, validate.WithRegistry(registry))
+				if err == nil || err.Error() != expected {
+					t.Errorf("expected error %q, but got %v", expected, err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
 type InnerWarning struct {
 	C int
 }
@@ -678,7 +1896,7 @@ func TestValidate_Warnings(t *testing.T) {
 				C: invalidInner,
 			},
 			nil,
-			errors.New("a should not be less than 3 but that is ok and c should not be less than 3 but that is ok"),
+			errors.New(`a should not be less than 3 but that is ok and "C" c should not be less than 3 but that is ok`),
 		},
 		{
 			"all warnings and errors",
@@ -688,7 +1906,19 @@ func TestValidate_Warnings(t *testing.T) {
 				C: invalidInner,
 			},
 			errors.New("\"b\" must be greater than 3"),
-			errors.New("a should not be less than 3 but that is ok and c should not be less than 3 but that is ok"),
+			errors.New(`a should not be less than 3 but that is ok and "C" c should not be less than 3 but that is ok`),
+		},
+		{
+			"field and item warnings",
+			&struct {
+				C  *InnerWarning   `validate:"struct"`
+				Cs []*InnerWarning `validate:"items" validateItems:"struct"`
+			}{
+				C:  invalidInner,
+				Cs: []*InnerWarning{validInner, invalidInner},
+			},
+			nil,
+			errors.New(`"C" c should not be less than 3 but that is ok and "Cs" [1] c should not be less than 3 but that is ok`),
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -735,3 +1965,290 @@ func boolPtr(b bool) *bool {
 func stringPtr(s string) *string {
 	return &s
 }
+
+//go:generate go run ./cmd/validate-gen -type generatedAccount validate_test.go
+
+type generatedAccount struct {
+	Name     string            `validate:"notempty,maxlen(8),match(^[a-z]+$)"`
+	Age      int8              `validate:"gte(18)"`
+	Balance  float64           `validate:"lt(1000.5)"`
+	Role     string            `validate:"in(admin, user)"`
+	Tags     []string          `validate:"notnil,minlen(1)"`
+	Verified bool              `validate:"eq(true)"`
+	Attrs    map[string]string `validate:"zero"`
+	code     uint              `validate:"neq(0)"`
+	Ignored  string
+}
+
+// reflectedAccount is validated by reflecting over the struct tags of generatedAccount.
+type reflectedAccount generatedAccount
+
+func TestValidate_Generated(t *testing.T) {
+	registry := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+		UseGeneratedValidators().
+		Build()
+	valid := generatedAccount{Name: "bob", Age: 30, Balance: 10, Role: "user", Tags: []string{"a"}, Verified: true, code: 1}
+	invalid := generatedAccount{Name: "Robert Jr", Age: 12, Balance: 1000.5, Role: "root", Tags: []string{}, Attrs: map[string]string{}}
+
+	for _, tc := range []struct {
+		name     string
+		instance generatedAccount
+		options  []validate.Option
+		err      string
+	}{
+		{"pass", valid, nil, ""},
+		{"fail", invalid, nil, `"Name" must have max length 8 and must match "^[a-z]+$" and "Age" must be greater than or equal to 18 and "Balance" must be less than 1000.5 and "Role" must be one of [admin user] and "Tags" must have min length 1 and "Verified" must be equal to true and "Attrs" must be "map[]" and "code" must not be equal to 0`},
+		{"fail stop on error", invalid, []validate.Option{validate.WithStopOnError()}, `"Name" must have max length 8`},
+		{"fail field mask", invalid, []validate.Option{validate.WithFieldMask([]string{"Age", "Role"})}, `"Age" must be greater than or equal to 18 and "Role" must be one of [admin user]`},
+		{"fail exclude fields", generatedAccount{Name: "", Age: 18, Tags: []string{"a"}, Verified: true, code: 1}, []validate.Option{validate.WithExcludeFields([]string{"Role"})}, `"Name" must not be empty and must match "^[a-z]+$"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err, _ := validate.Validate(tc.instance, append([]validate.Option{validate.WithRegistry(registry)}, tc.options...)...)
+			if err == nil && tc.err != "" {
+				t.Fatalf("expected error %v, but got none", tc.err)
+			} else if err != nil && err.Error() != tc.err {
+				t.Fatalf("expected error %q, but got %v", tc.err, err)
+			}
+
+			rerr, _ := validate.Validate(reflectedAccount(tc.instance), tc.options...)
+			if (err == nil) != (rerr == nil) {
+				t.Fatalf("expected generated error %v to match reflected error %v", err, rerr)
+			}
+			if err == nil {
+				return
+			}
+			if err.Error() != rerr.Error() {
+				t.Fatalf("expected generated error %q to match reflected error %q", err, rerr)
+			}
+
+			fes := err.(*validate.ValidationErrors).FieldErrors()
+			rfes := rerr.(*validate.ValidationErrors).FieldErrors()
+			if !reflect.DeepEqual(fes, rfes) {
+				t.Fatalf("expected generated field errors %+v to match reflected field errors %+v", fes, rfes)
+			}
+		})
+	}
+
+	t.Run("opt in", func(t *testing.T) {
+		failing := validate.TagValidatorFactoryFunc(func(ctx validate.ResolutionContext, name string, args []string) (validate.Validator, error) {
+			return validate.ValidatorFunc(func(validate.Context) (error, error) {
+				return errors.New("reflected"), nil
+			}), nil
+		})
+		reflected := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+			RegisterTagValidatorFactory("notempty", failing).
+			Build()
+
+		if err, _ := validate.Validate(&valid, validate.WithRegistry(reflected)); err == nil || err.Error() != `"Name" reflected` {
+			t.Fatalf("expected error %q, but got %v", `"Name" reflected`, err)
+		}
+
+		generated := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+			RegisterTagValidatorFactory("notempty", failing).
+			UseGeneratedValidators().
+			Build()
+		if err, _ := validate.Validate(&valid, validate.WithRegistry(generated)); err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+	})
+}
+
+type compiledInner struct {
+	Count []int `validate:"dive,gt(x)"`
+}
+
+type compiledOuter struct {
+	Name   string          `validate:"minlen(3),bogus"`
+	Age    int             `validate:"len(3)"`
+	Inner  compiledInner   `validate:"struct"`
+	Inners []compiledInner `validate:"items" validateItems:"struct"`
+	Tags   []string        `validate:"notempty,"`
+	Valid  string          `validate:"notempty"`
+}
+
+type compiledGroups struct {
+	Name string `validate:"create:bogus"`
+	Kind string `validate:"bogus1|bogus2;update:minlen(x)"`
+}
+
+func TestRegistry_Compile(t *testing.T) {
+	registry := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+		Preload(reflect.TypeOf(compiledOuter{})).
+		Build()
+
+	err := registry.Compile(reflect.TypeOf(&selfValidator{}))
+	ce, ok := err.(validate.CompileError)
+	if !ok {
+		t.Fatalf("expected validate.CompileError, but got %T: %v", err, err)
+	}
+
+	expected := []string{
+		"validate_test.compiledOuter.Name: no validator factory found for bogus",
+		"validate_test.compiledOuter.Age: (len) only pointers to/or strings, arrays, slices, and maps are supported",
+		"validate_test.compiledInner.Count: (gt) argument must be an integer",
+		`validate_test.compiledOuter.Tags: invalid validate tag "notempty," at offset 9: expected validator name`,
+	}
+	if len(ce.Errors) != len(expected) {
+		t.Fatalf("expected %d errors, but got %d: %v", len(expected), len(ce.Errors), err)
+	}
+	for i, e := range expected {
+		if ce.Errors[i].Error() != e {
+			t.Errorf("expected error %d to be %q, but got %q", i, e, ce.Errors[i])
+		}
+	}
+
+	if _, ok := ce.Errors[1].(validate.ResolutionError); !ok {
+		t.Fatalf("expected validate.ResolutionError, but got %T", ce.Errors[1])
+	}
+
+	valid := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+		Build().
+		MustCompile(reflect.TypeOf(selfValidator{}), reflect.TypeOf(&uniqueUser{}))
+	if _, err := valid.LookupValidator(reflect.TypeOf(uniqueUser{})); err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	err = valid.Compile(reflect.TypeOf(compiledGroups{}))
+	ce, ok = err.(validate.CompileError)
+	if !ok {
+		t.Fatalf("expected validate.CompileError, but got %T: %v", err, err)
+	}
+
+	expected = []string{
+		"validate_test.compiledGroups.Name: no validator factory found for bogus",
+		"validate_test.compiledGroups.Kind: no validator factory found for bogus1",
+		"validate_test.compiledGroups.Kind: no validator factory found for bogus2",
+		"validate_test.compiledGroups.Kind: (minlen) argument must be an integer",
+	}
+	if len(ce.Errors) != len(expected) {
+		t.Fatalf("expected %d errors, but got %d: %v", len(expected), len(ce.Errors), err)
+	}
+	for i, e := range expected {
+		if ce.Errors[i].Error() != e {
+			t.Errorf("expected error %d to be %q, but got %q", i, e, ce.Errors[i])
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected a panic, but got none")
+		}
+	}()
+	registry.MustCompile()
+}
+
+func TestRegistry_LookupValidator_Concurrent(t *testing.T) {
+	type counted struct {
+		Name string `validate:"counted"`
+	}
+	type failing struct {
+		Name string `validate:"failing"`
+	}
+
+	var created, failed int32
+	registry := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+		RegisterTagValidatorFactory("counted", validate.TagValidatorFactoryFunc(func(ctx validate.ResolutionContext, name string, args []string) (validate.Validator, error) {
+			atomic.AddInt32(&created, 1)
+			time.Sleep(10 * time.Millisecond)
+			return validate.NotEmpty(), nil
+		})).
+		RegisterTagValidatorFactory("failing", validate.TagValidatorFactoryFunc(func(ctx validate.ResolutionContext, name string, args []string) (validate.Validator, error) {
+			atomic.AddInt32(&failed, 1)
+			return nil, errors.New("failed")
+		})).
+		Build()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := registry.LookupValidator(reflect.TypeOf(counted{})); err != nil {
+				t.Errorf("expected no error, but got %v", err)
+			}
+			if _, err := registry.LookupValidator(reflect.TypeOf(failing{})); err == nil {
+				t.Error("expected an error, but got none")
+			}
+			if _, err := registry.LookupValidator(reflect.TypeOf(make(chan int))); err == nil {
+				t.Error("expected an error, but got none")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Fatalf("expected the validator to be resolved once, but it was resolved %d times", created)
+	}
+	if failed != 1 {
+		t.Fatalf("expected the failure to be cached, but it was resolved %d times", failed)
+	}
+}
+
+type slowA struct {
+	Name string `validate:"slow"`
+	B    *slowB `validate:"struct"`
+}
+
+type slowB struct {
+	Name string `validate:"slow"`
+	A    *slowA `validate:"struct"`
+}
+
+func TestRegistry_LookupValidator_ConcurrentNested(t *testing.T) {
+	type shared struct {
+		Name string `validate:"slow"`
+	}
+	type first struct {
+		Shared shared `validate:"struct"`
+	}
+	type second struct {
+		Shared shared `validate:"struct"`
+	}
+
+	var created int32
+	registry := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+		RegisterTagValidatorFactory("slow", validate.TagValidatorFactoryFunc(func(ctx validate.ResolutionContext, name string, args []string) (validate.Validator, error) {
+			atomic.AddInt32(&created, 1)
+			time.Sleep(10 * time.Millisecond)
+			return validate.NotEmpty(), nil
+		})).
+		Build()
+
+	var wg sync.WaitGroup
+	for _, instance := range []interface{}{first{}, second{}, first{}, second{}} {
+		wg.Add(1)
+		go func(typ reflect.Type) {
+			defer wg.Done()
+			if _, err := registry.LookupValidator(typ); err != nil {
+				t.Errorf("expected no error, but got %v", err)
+			}
+		}(reflect.TypeOf(instance))
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Fatalf("expected the nested validator to be resolved once, but it was resolved %d times", created)
+	}
+
+	// Resolutions of types referring to each other wait for each other's, which mustn't deadlock.
+	for run := 0; run < 20; run++ {
+		registry := validate.RegisterDefaultTagValidatorFactories(validate.NewRegistryBuilder()).
+			RegisterTagValidatorFactory("slow", validate.TagValidatorFactoryFunc(func(ctx validate.ResolutionContext, name string, args []string) (validate.Validator, error) {
+				time.Sleep(time.Millisecond)
+				return validate.NotEmpty(), nil
+			})).
+			Build()
+
+		var wg sync.WaitGroup
+		for _, instance := range []interface{}{slowA{}, slowB{}, &slowA{}, &slowB{}} {
+			wg.Add(1)
+			go func(typ reflect.Type) {
+				defer wg.Done()
+				if _, err := registry.LookupValidator(typ); err != nil {
+					t.Errorf("expected no error, but got %v", err)
+				}
+			}(reflect.TypeOf(instance))
+		}
+		wg.Wait()
+	}
+}
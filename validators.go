@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Validator performs validation on a value and returns an error and a warning.
@@ -13,6 +16,14 @@ type Validator interface {
 	Validate(Context) (error, error)
 }
 
+// GeneratedValidator is implemented by the code validate-gen generates from the struct tags of
+// a type. Structs whose pointers implement it are validated by the generated code rather than
+// by reflecting over their fields when the registry uses generated validators, as the
+// DefaultRegistry does.
+type GeneratedValidator interface {
+	ValidateGenerated(Context) (error, error)
+}
+
 // ValidatorFunc is an adapter for a function that implements the Validator interface.
 type ValidatorFunc func(Context) (error, error)
 
@@ -34,10 +45,17 @@ func And(validators ...Validator) Validator {
 		var errs []error
 		var warnings []error
 		for _, v := range validators {
+			if err := ctx.canceled(); err != nil {
+				return err, nil
+			}
+
 			err, warning := v.Validate(ctx)
+			if isCanceled(err) {
+				return err, nil
+			}
 			if err != nil {
 				errs = append(errs, err)
-				if ctx.Options.StopOnError {
+				if ctx.Options.StopOnError || hasSeverity(err, SeverityFatal) {
 					break
 				}
 			}
@@ -58,18 +76,54 @@ func CustomMessage(validator Validator, msg string) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		err, warning := validator.Validate(ctx)
 		if err != nil {
-			switch err.(type) {
-			case *Error:
-				return newError(msg), warning
-			default:
-				return err, warning
+			if isValidationError(err) {
+				return replaceMessage(err, msg), warning
 			}
+			return err, warning
 		}
 
 		return nil, warning
 	})
 }
 
+// replaceMessage collapses a validation error into a single FieldError with the msg. The
+// path is retained, but is not rendered beyond the segments added by enclosing validators.
+func replaceMessage(err error, msg string) *FieldError {
+	var fe FieldError
+	switch e := err.(type) {
+	case *FieldError:
+		fe = *e
+	case *ValidationErrors:
+		fes := e.FieldErrors()
+		if len(fes) == 1 {
+			fe = fes[0]
+		} else {
+			fe = FieldError{Path: commonPath(fes)}
+		}
+	}
+
+	fe.Message = msg
+	fe.hiddenPath = len(fe.Path)
+	return &fe
+}
+
+func commonPath(fes []FieldError) Path {
+	if len(fes) == 0 {
+		return nil
+	}
+
+	p := fes[0].Path
+	for _, fe := range fes[1:] {
+		n := 0
+		for n < len(p) && n < len(fe.Path) && p[n] == fe.Path[n] {
+			n++
+		}
+		p = p[:n]
+	}
+
+	return p
+}
+
 // Empty requires the length of a string, array, slice, or map to be 0.
 func Empty() Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
@@ -77,11 +131,11 @@ func Empty() Validator {
 		switch val.Kind() {
 		case reflect.String, reflect.Array, reflect.Slice, reflect.Map:
 			if val.Len() != 0 {
-				return newError("must be empty"), nil
+				return newFieldError(ctx, "empty", nil, "must be empty"), nil
 			}
 			return nil, nil
 		case reflect.Ptr:
-			return newError("must be empty"), nil
+			return newFieldError(ctx, "empty", nil, "must be empty"), nil
 		default:
 			return isEmptyAllowed(val.Type(), "empty", nil), nil
 		}
@@ -99,27 +153,67 @@ func isEmptyAllowed(t reflect.Type, name string, args []string) error {
 	}
 }
 
-// Equal requires the value to be equal to the specified value.
+// Equal requires the value to be equal to the specified value. Values of a type T with a method
+//
+//	Compare(other T) int
+//
+// returning a negative number, 0, or a positive number when the value is less than, equal to, or
+// greater than the other are compared with it, and may only be compared with others of type T. The
+// method may have a pointer receiver. When created from a struct tag, arguments are parsed into
+// values of such a type with its method
+//
+//	ParseValidateArg(arg string) (T, error)
+//
+// which types of a primitive kind, such as a string-based version, don't need. Other values are
+// compared by their kind, with all numbers comparable with each other.
 func Equal(other interface{}) Validator {
+	return equal(other, cmp)
+}
+
+func equal(other interface{}, compare compareFunc) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		val := indirect(ctx.Value)
 		if val.Kind() == reflect.Ptr {
-			return newErrorf("must be equal to %v", other), nil
+			return newFieldErrorf(ctx, "eq", []interface{}{other}, "must be equal to %v", other), nil
 		}
 
-		r, err := cmp(val, reflect.ValueOf(other))
+		r, err := compare(val, argValue(ctx, other))
 		if err != nil {
 			return err, nil
 		}
 
 		if r != 0 {
-			return newErrorf("must be equal to %v", other), nil
+			return newFieldErrorf(ctx, "eq", []interface{}{other}, "must be equal to %v", other), nil
 		}
 
 		return nil, nil
 	})
 }
 
+// EqualField requires the value to be equal to the referenced field. A reference is relative to the
+// enclosing struct, such as "Password" for a sibling, may reach into structs enclosing it with a leading
+// dot per level, such as ".Order.Currency", or may start at the outermost struct, such as "$.Order.Currency".
+// See Equal for how values are compared.
+func EqualField(ref string) Validator {
+	return equalField(ref, cmp)
+}
+
+func equalField(ref string, compare compareFunc) Validator {
+	return compareField(ref, "eqfield", "must be equal to", compare, func(r int) bool { return r == 0 })
+}
+
+// ExcludedIf requires the value to be absent, being the zero value, when the referenced field is equal
+// to the specified value. See EqualField for the form of a reference.
+func ExcludedIf(ref string, value interface{}) Validator {
+	return presenceIf("excluded_if", ref, value, true, false, "must be absent when field %q is %v")
+}
+
+// ExcludedWith requires the value to be absent, being the zero value, when any of the referenced fields
+// are present. See EqualField for the form of a reference.
+func ExcludedWith(refs ...string) Validator {
+	return presenceWith("excluded_with", refs, true, false, "must be absent when field %q is present")
+}
+
 // Field wraps a validator in a field validator.
 func Field(name string, validator Validator) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
@@ -135,74 +229,108 @@ func Field(name string, validator Validator) Validator {
 		fctx := ctx
 		fctx.Parent = &ctx
 		fctx.Value = val
+		if !fctx.selects(fieldSegment(name)) {
+			return nil, nil
+		}
 
 		err, warning := validator.Validate(fctx)
+		if warning != nil {
+			warning = prefixWarning(warning, fieldSegment(name))
+		}
 		if err != nil {
-			switch err.(type) {
-			case *Error:
-				sf, _ := ctx.Value.Type().FieldByName(name)
-				return newErrorf("%q %v", sf.Name, err), warning
-			default:
-				return err, warning
-			}
+			return prefixPath(err, fieldSegment(name)), warning
 		}
 
 		return nil, warning
 	})
 }
 
-// GreaterThan requires the value to be greater than the specified value.
+// GreaterThan requires the value to be greater than the specified value. See Equal for how values
+// are compared.
 func GreaterThan(other interface{}) Validator {
+	return greaterThan(other, cmp)
+}
+
+func greaterThan(other interface{}, compare compareFunc) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		val := indirect(ctx.Value)
 		if val.Kind() == reflect.Ptr {
-			return newErrorf("must be greater than %v", other), nil
+			return newFieldErrorf(ctx, "gt", []interface{}{other}, "must be greater than %v", other), nil
 		}
 
-		r, err := cmp(val, reflect.ValueOf(other))
+		r, err := compare(val, argValue(ctx, other))
 		if err != nil {
 			return err, nil
 		}
 
 		if r <= 0 {
-			return newErrorf("must be greater than %v", other), nil
+			return newFieldErrorf(ctx, "gt", []interface{}{other}, "must be greater than %v", other), nil
 		}
 
 		return nil, nil
 	})
 }
 
-// GreaterThanOrEqual requires the value to be greater than or equal to the specified value.
+// GreaterThanField requires the value to be greater than the referenced field. See EqualField for
+// the form of a reference.
+func GreaterThanField(ref string) Validator {
+	return greaterThanField(ref, cmp)
+}
+
+func greaterThanField(ref string, compare compareFunc) Validator {
+	return compareField(ref, "gtfield", "must be greater than", compare, func(r int) bool { return r > 0 })
+}
+
+// GreaterThanOrEqual requires the value to be greater than or equal to the specified value. See Equal for how values
+// are compared.
 func GreaterThanOrEqual(other interface{}) Validator {
+	return greaterThanOrEqual(other, cmp)
+}
+
+func greaterThanOrEqual(other interface{}, compare compareFunc) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		val := indirect(ctx.Value)
 		if val.Kind() == reflect.Ptr {
-			return newErrorf("must be greater than or equal to %v", other), nil
+			return newFieldErrorf(ctx, "gte", []interface{}{other}, "must be greater than or equal to %v", other), nil
 		}
 
-		r, err := cmp(val, reflect.ValueOf(other))
+		r, err := compare(val, argValue(ctx, other))
 		if err != nil {
 			return err, nil
 		}
 
 		if r < 0 {
-			return newErrorf("must be greater than or equal to %v", other), nil
+			return newFieldErrorf(ctx, "gte", []interface{}{other}, "must be greater than or equal to %v", other), nil
 		}
 
 		return nil, nil
 	})
 }
 
-// In requires a value to be one of the specified values.
+// GreaterThanOrEqualField requires the value to be greater than or equal to the referenced field. See
+// EqualField for the form of a reference.
+func GreaterThanOrEqualField(ref string) Validator {
+	return greaterThanOrEqualField(ref, cmp)
+}
+
+func greaterThanOrEqualField(ref string, compare compareFunc) Validator {
+	return compareField(ref, "gtefield", "must be greater than or equal to", compare, func(r int) bool { return r >= 0 })
+}
+
+// In requires a value to be one of the specified values. See Equal for how values are compared.
 func In(values ...interface{}) Validator {
+	return in(values, cmp)
+}
+
+func in(values []interface{}, compare compareFunc) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		val := indirect(ctx.Value)
 		if val.Kind() == reflect.Ptr {
-			return newErrorf("must be one of %v", values), nil
+			return newFieldErrorf(ctx, "in", values, "must be one of %v", values), nil
 		}
 
 		for _, v := range values {
-			r, err := cmp(val, reflect.ValueOf(v))
+			r, err := compare(val, argValue(ctx, v))
 			if err != nil {
 				return err, nil
 			}
@@ -212,7 +340,7 @@ func In(values ...interface{}) Validator {
 			}
 		}
 
-		return newErrorf("must be one of %v", values), nil
+		return newFieldErrorf(ctx, "in", values, "must be one of %v", values), nil
 	})
 }
 
@@ -226,26 +354,31 @@ func Items(validator Validator) Validator {
 		case reflect.Array, reflect.Slice:
 			len := val.Len()
 			for i := 0; i < len; i++ {
+				if err := ctx.canceled(); err != nil {
+					return err, nil
+				}
+
 				item := val.Index(i)
 
 				fctx := ctx
 				fctx.Parent = &ctx
 				fctx.Value = item
+				if !fctx.selects(indexSegment(i)) {
+					continue
+				}
 
 				err, warning := validator.Validate(fctx)
 				if err != nil {
-					switch err.(type) {
-					case *Error:
-						errs = append(errs, newErrorf("[%d] %v", i, err))
-						if ctx.Options.StopOnError {
-							break
-						}
-					default:
+					if !isValidationError(err) {
 						return err, warning
 					}
+					errs = append(errs, prefixPath(err, indexSegment(i)))
+					if ctx.Options.StopOnError {
+						break
+					}
 				}
 				if warning != nil {
-					warnings = append(warnings, newErrorf("[%d] %v", i, warning))
+					warnings = append(warnings, prefixWarning(warning, indexSegment(i)))
 					if ctx.Options.shouldStopOnWarnings() {
 						break
 					}
@@ -254,26 +387,31 @@ func Items(validator Validator) Validator {
 		case reflect.Map:
 			mi := val.MapRange()
 			for mi.Next() {
+				if err := ctx.canceled(); err != nil {
+					return err, nil
+				}
+
 				item := mi.Value()
 
 				fctx := ctx
 				fctx.Parent = &ctx
 				fctx.Value = item
+				if !fctx.selects(keySegment(mi.Key())) {
+					continue
+				}
 
 				err, warning := validator.Validate(fctx)
 				if err != nil {
-					switch err.(type) {
-					case *Error:
-						errs = append(errs, newErrorf("[%v] %v", mi.Key(), err))
-						if ctx.Options.StopOnError {
-							break
-						}
-					default:
+					if !isValidationError(err) {
 						return err, warning
 					}
+					errs = append(errs, prefixPath(err, keySegment(mi.Key())))
+					if ctx.Options.StopOnError {
+						break
+					}
 				}
 				if warning != nil {
-					warnings = append(warnings, newErrorf("[%v] %v", mi.Key(), warning))
+					warnings = append(warnings, prefixWarning(warning, keySegment(mi.Key())))
 					if ctx.Options.shouldStopOnWarnings() {
 						break
 					}
@@ -287,6 +425,302 @@ func Items(validator Validator) Validator {
 	})
 }
 
+// Keys validates that all the keys of a map.
+func Keys(validator Validator) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		val := indirect(ctx.Value)
+		if val.Kind() != reflect.Map {
+			return isKeysAllowed(ctx.Value.Type(), "keys", nil), nil
+		}
+
+		var errs []error
+		var warnings []error
+		mi := val.MapRange()
+		for mi.Next() {
+			if err := ctx.canceled(); err != nil {
+				return err, nil
+			}
+
+			key := mi.Key()
+
+			fctx := ctx
+			fctx.Parent = &ctx
+			fctx.Value = key
+			if !fctx.selects(mapKeySegment(key)) {
+				continue
+			}
+
+			err, warning := validator.Validate(fctx)
+			if err != nil {
+				if !isValidationError(err) {
+					return err, warning
+				}
+				errs = append(errs, prefixPath(err, mapKeySegment(key)))
+				if ctx.Options.StopOnError {
+					break
+				}
+			}
+			if warning != nil {
+				warnings = append(warnings, prefixWarning(warning, mapKeySegment(key)))
+				if ctx.Options.shouldStopOnWarnings() {
+					break
+				}
+			}
+		}
+
+		return mergeIntoWarningsAndErrors(warnings, errs, "and")
+	})
+}
+
+func isKeysAllowed(t reflect.Type, name string, args []string) error {
+	switch t.Kind() {
+	case reflect.Map:
+		return nil
+	case reflect.Ptr:
+		return isKeysAllowed(t.Elem(), name, args)
+	default:
+		return InvalidTagArgumentsError{Message: "only pointers to/or maps are supported", ValidatorName: name, Args: args}
+	}
+}
+
+// Unique validates that the items of a slice or array, or the values of a map, are distinct.
+func Unique() Validator {
+	return unique("unique", nil, "items", indirect)
+}
+
+// UniqueBy validates that the keys taken from the items of a slice or array, or the values of a
+// map, are distinct.
+func UniqueBy(key func(reflect.Value) interface{}) Validator {
+	return unique("unique", nil, "items", func(item reflect.Value) reflect.Value {
+		return reflect.ValueOf(key(item))
+	})
+}
+
+// uniqueField validates that the named field of the structs held by a slice, array, or map
+// is distinct.
+func uniqueField(name string) Validator {
+	return unique("unique", []interface{}{name}, name, func(item reflect.Value) reflect.Value {
+		item = indirect(item)
+		if item.Kind() == reflect.Ptr {
+			return item
+		}
+
+		return indirect(item.FieldByName(name))
+	})
+}
+
+func unique(name string, args []interface{}, what string, keyOf func(reflect.Value) reflect.Value) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		val := indirect(ctx.Value)
+
+		var segs []PathSegment
+		var keys []reflect.Value
+		switch val.Kind() {
+		case reflect.Array, reflect.Slice:
+			for i := 0; i < val.Len(); i++ {
+				segs = append(segs, indexSegment(i))
+				keys = append(keys, keyOf(val.Index(i)))
+			}
+		case reflect.Map:
+			mapKeys := val.MapKeys()
+			sort.Slice(mapKeys, func(i, j int) bool {
+				return fmt.Sprint(interfaceOf(mapKeys[i])) < fmt.Sprint(interfaceOf(mapKeys[j]))
+			})
+			for _, k := range mapKeys {
+				segs = append(segs, keySegment(k))
+				keys = append(keys, keyOf(val.MapIndex(k)))
+			}
+		case reflect.Ptr:
+			return nil, nil
+		default:
+			return isItemsAllowed(val.Type(), name, nil), nil
+		}
+
+		var errs []error
+		for _, pair := range duplicates(keys) {
+			errs = append(errs, newFieldErrorf(ctx, name, args, "must have unique %s, but %s and %s are equal", what, segs[pair[0]], segs[pair[1]]))
+			if ctx.Options.StopOnError {
+				break
+			}
+		}
+
+		return mergeIntoWarningsAndErrors(nil, errs, "and")
+	})
+}
+
+// duplicates finds each of the keys equal to an earlier one, paired with the first of those.
+// Scalars and other values that can be hashed without changing what equalValues considers equal
+// are looked up in a map, and anything else is compared with each of the earlier keys.
+func duplicates(keys []reflect.Value) [][2]int {
+	var pairs [][2]int
+	seen := make(map[interface{}]int)
+	for j, k := range keys {
+		for k.IsValid() && k.Kind() == reflect.Interface {
+			k = k.Elem()
+		}
+
+		if h, ok := hashKey(k); ok {
+			if i, ok := seen[h]; ok {
+				pairs = append(pairs, [2]int{i, j})
+			} else {
+				seen[h] = j
+			}
+			continue
+		}
+		for i := 0; i < j; i++ {
+			if equalValues(keys[i], keys[j]) {
+				pairs = append(pairs, [2]int{i, j})
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// scalarKey is the map key of a scalar, whose value is normalized the way cmp reads it.
+type scalarKey struct {
+	t reflect.Type
+	v interface{}
+}
+
+// hashKey returns a map key that is equal for values equalValues considers equal.
+func hashKey(v reflect.Value) (interface{}, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+
+	switch cmpKind(v.Type()) {
+	case reflect.Bool:
+		if v.Kind() == reflect.Bool {
+			return scalarKey{v.Type(), v.Bool()}, true
+		}
+	case reflect.String:
+		if v.Kind() == reflect.String {
+			return scalarKey{v.Type(), v.String()}, true
+		}
+	case reflect.Float64:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return scalarKey{v.Type(), v.Int()}, true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return scalarKey{v.Type(), v.Uint()}, true
+		case reflect.Float32, reflect.Float64:
+			return scalarKey{v.Type(), v.Float()}, true
+		}
+	case reflect.Invalid:
+		if hashable(v.Type()) {
+			return v.Interface(), true
+		}
+	}
+
+	return nil, false
+}
+
+// hashable indicates whether values of the type can be hashed without changing what equalValues
+// considers equal. Interfaces may hold values that can't be hashed at all, and times and types
+// with a Compare method are equal by something other than their representation.
+func hashable(t reflect.Type) bool {
+	if !t.Comparable() || t == tTime || hasCompareMethod(t) {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return hashable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !hashable(t.Field(i).Type) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func equalValues(a reflect.Value, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	if a.Type() == tTime || hasCompareMethod(a.Type()) {
+		r, err := cmp(a, b)
+		return err == nil && r == 0
+	}
+
+	switch a.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		r, err := cmp(a, b)
+		return err == nil && r == 0
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !equalValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equalValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Interface:
+		return equalValues(a.Elem(), b.Elem())
+	default:
+		if a.CanInterface() && b.CanInterface() {
+			return reflect.DeepEqual(a.Interface(), b.Interface())
+		}
+		return false
+	}
+}
+
+func isUniqueAllowed(t reflect.Type, name string, args []string) error {
+	if err := isItemsAllowed(t, name, args); err != nil {
+		return err
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	item := t.Elem()
+	for item.Kind() == reflect.Ptr {
+		item = item.Elem()
+	}
+
+	switch len(args) {
+	case 0:
+	case 1:
+		if item.Kind() != reflect.Struct {
+			return InvalidTagArgumentsError{Message: "a field can only be used with items that are structs", ValidatorName: name, Args: args}
+		}
+		sf, ok := item.FieldByName(args[0])
+		if !ok {
+			return InvalidTagArgumentsError{Message: fmt.Sprintf("%s has no field %q", item, args[0]), ValidatorName: name, Args: args}
+		}
+		item = sf.Type
+	default:
+		return InvalidTagArgumentsError{Message: "at most 1 argument is allowed", ValidatorName: name, Args: args}
+	}
+
+	if !item.Comparable() {
+		return InvalidTagArgumentsError{Message: fmt.Sprintf("%s is not comparable", item), ValidatorName: name, Args: args}
+	}
+
+	return nil
+}
+
 func isItemsAllowed(t reflect.Type, name string, args []string) error {
 	switch t.Kind() {
 	case reflect.Array, reflect.Slice, reflect.Map:
@@ -305,11 +739,11 @@ func Length(len int) Validator {
 		switch val.Kind() {
 		case reflect.String, reflect.Array, reflect.Slice, reflect.Map:
 			if val.Len() != len {
-				return newErrorf("must be of length %d", len), nil
+				return newFieldErrorf(ctx, "len", []interface{}{len}, "must be of length %d", len), nil
 			}
 			return nil, nil
 		case reflect.Ptr:
-			return newErrorf("must be of length %d", len), nil
+			return newFieldErrorf(ctx, "len", []interface{}{len}, "must be of length %d", len), nil
 		default:
 			return isLengthAllowed(val.Type(), "length", nil), nil
 		}
@@ -337,48 +771,118 @@ func isLengthAllowed(t reflect.Type, name string, args []string) error {
 	return nil
 }
 
-// LessThan requires the value to be less than the specified value.
+// LessThan requires the value to be less than the specified value. See Equal for how values
+// are compared.
 func LessThan(other interface{}) Validator {
+	return lessThan(other, cmp)
+}
+
+func lessThan(other interface{}, compare compareFunc) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		val := indirect(ctx.Value)
 		if val.Kind() == reflect.Ptr {
-			return newErrorf("must be less than %v", other), nil
+			return newFieldErrorf(ctx, "lt", []interface{}{other}, "must be less than %v", other), nil
 		}
 
-		r, err := cmp(val, reflect.ValueOf(other))
+		r, err := compare(val, argValue(ctx, other))
 		if err != nil {
 			return err, nil
 		}
 
 		if r >= 0 {
-			return newErrorf("must be less than %v", other), nil
+			return newFieldErrorf(ctx, "lt", []interface{}{other}, "must be less than %v", other), nil
 		}
 
 		return nil, nil
 	})
 }
 
-// LessThanOrEqual requires the value to be less than or equal to the specified value.
+// LessThanField requires the value to be less than the referenced field. See EqualField for the form
+// of a reference.
+func LessThanField(ref string) Validator {
+	return lessThanField(ref, cmp)
+}
+
+func lessThanField(ref string, compare compareFunc) Validator {
+	return compareField(ref, "ltfield", "must be less than", compare, func(r int) bool { return r < 0 })
+}
+
+// LessThanOrEqual requires the value to be less than or equal to the specified value. See Equal for how values
+// are compared.
 func LessThanOrEqual(other interface{}) Validator {
+	return lessThanOrEqual(other, cmp)
+}
+
+func lessThanOrEqual(other interface{}, compare compareFunc) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		val := indirect(ctx.Value)
 		if val.Kind() == reflect.Ptr {
-			return newErrorf("must be less than or equal to %v", other), nil
+			return newFieldErrorf(ctx, "lte", []interface{}{other}, "must be less than or equal to %v", other), nil
 		}
 
-		r, err := cmp(val, reflect.ValueOf(other))
+		r, err := compare(val, argValue(ctx, other))
 		if err != nil {
 			return err, nil
 		}
 
 		if r > 0 {
-			return newErrorf("must be less than or equal to %v", other), nil
+			return newFieldErrorf(ctx, "lte", []interface{}{other}, "must be less than or equal to %v", other), nil
 		}
 
 		return nil, nil
 	})
 }
 
+// LessThanOrEqualField requires the value to be less than or equal to the referenced field. See
+// EqualField for the form of a reference.
+func LessThanOrEqualField(ref string) Validator {
+	return lessThanOrEqualField(ref, cmp)
+}
+
+func lessThanOrEqualField(ref string, compare compareFunc) Validator {
+	return compareField(ref, "ltefield", "must be less than or equal to", compare, func(r int) bool { return r <= 0 })
+}
+
+// Match requires a string to match the regular expression.
+func Match(re *regexp.Regexp) Validator {
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		val := indirect(ctx.Value)
+		switch val.Kind() {
+		case reflect.String:
+			if !re.MatchString(val.String()) {
+				return newFieldErrorf(ctx, "match", []interface{}{re.String()}, "must match %q", re.String()), nil
+			}
+			return nil, nil
+		case reflect.Ptr:
+			return newFieldErrorf(ctx, "match", []interface{}{re.String()}, "must match %q", re.String()), nil
+		default:
+			_, err := isMatchAllowed(val.Type(), "match", []string{re.String()})
+			return err, nil
+		}
+	})
+}
+
+func isMatchAllowed(t reflect.Type, name string, args []string) (*regexp.Regexp, error) {
+	switch t.Kind() {
+	case reflect.String:
+	case reflect.Ptr:
+		return isMatchAllowed(t.Elem(), name, args)
+	default:
+		return nil, InvalidTagArgumentsError{Message: "only pointers to/or strings are supported", ValidatorName: name, Args: args}
+	}
+
+	if len(args) != 1 {
+		return nil, InvalidTagArgumentsError{Message: "1 argument is required", ValidatorName: name, Args: args}
+	}
+
+	re, err := regexp.Compile(args[0])
+	if err != nil {
+		return nil, InvalidTagArgumentsError{Message: "argument must be a valid regular expression: " + err.Error(), ValidatorName: name, Args: args}
+	}
+
+	return re, nil
+}
+
 // MaxLength requires the length of a string, array, slice, or map to be less than or equal to len.
 func MaxLength(len int) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
@@ -386,11 +890,11 @@ func MaxLength(len int) Validator {
 		switch val.Kind() {
 		case reflect.String, reflect.Array, reflect.Slice, reflect.Map:
 			if val.Len() > len {
-				return newErrorf("must have max length %d", len), nil
+				return newFieldErrorf(ctx, "maxlen", []interface{}{len}, "must have max length %d", len), nil
 			}
 			return nil, nil
 		case reflect.Ptr:
-			return newErrorf("must have max length %d", len), nil
+			return newFieldErrorf(ctx, "maxlen", []interface{}{len}, "must have max length %d", len), nil
 		default:
 			return isLengthAllowed(val.Type(), "maxlength", nil), nil
 		}
@@ -404,11 +908,11 @@ func MinLength(len int) Validator {
 		switch val.Kind() {
 		case reflect.String, reflect.Array, reflect.Slice, reflect.Map:
 			if val.Len() < len {
-				return newErrorf("must have min length %d", len), nil
+				return newFieldErrorf(ctx, "minlen", []interface{}{len}, "must have min length %d", len), nil
 			}
 			return nil, nil
 		case reflect.Ptr:
-			return newErrorf("must have min length %d", len), nil
+			return newFieldErrorf(ctx, "minlen", []interface{}{len}, "must have min length %d", len), nil
 		default:
 			return isLengthAllowed(val.Type(), "minlength", nil), nil
 		}
@@ -421,7 +925,7 @@ func Nil() Validator {
 		switch ctx.Value.Kind() {
 		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
 			if !ctx.Value.IsNil() {
-				return newError("must be nil"), nil
+				return newFieldError(ctx, "nil", nil, "must be nil"), nil
 			}
 			return nil, nil
 		default:
@@ -454,11 +958,11 @@ func NotEmpty() Validator {
 		switch val.Kind() {
 		case reflect.String, reflect.Array, reflect.Slice, reflect.Map:
 			if val.Len() == 0 {
-				return newError("must not be empty"), nil
+				return newFieldError(ctx, "notempty", nil, "must not be empty"), nil
 			}
 			return nil, nil
 		case reflect.Ptr:
-			return newError("must not be empty"), nil
+			return newFieldError(ctx, "notempty", nil, "must not be empty"), nil
 		default:
 			return isNotEmptyAllowed(val.Type(), "notempty", nil), nil
 		}
@@ -476,34 +980,49 @@ func isNotEmptyAllowed(t reflect.Type, name string, args []string) error {
 	}
 }
 
-// NotEqual requires the value to not be equal to the specified value.
+// NotEqual requires the value to not be equal to the specified value. See Equal for how values
+// are compared.
 func NotEqual(other interface{}) Validator {
+	return notEqual(other, cmp)
+}
+
+func notEqual(other interface{}, compare compareFunc) Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		val := indirect(ctx.Value)
 		if val.Kind() == reflect.Ptr {
-			return newErrorf("must not be equal to %v", other), nil
+			return newFieldErrorf(ctx, "neq", []interface{}{other}, "must not be equal to %v", other), nil
 		}
 
-		r, err := cmp(val, reflect.ValueOf(other))
+		r, err := compare(val, argValue(ctx, other))
 		if err != nil {
 			return err, nil
 		}
 
 		if r == 0 {
-			return newErrorf("must not be equal to %v", other), nil
+			return newFieldErrorf(ctx, "neq", []interface{}{other}, "must not be equal to %v", other), nil
 		}
 
 		return nil, nil
 	})
 }
 
+// NotEqualField requires the value to not be equal to the referenced field. See EqualField for the
+// form of a reference.
+func NotEqualField(ref string) Validator {
+	return notEqualField(ref, cmp)
+}
+
+func notEqualField(ref string, compare compareFunc) Validator {
+	return compareField(ref, "nefield", "must not be equal to", compare, func(r int) bool { return r != 0 })
+}
+
 // NotNil requires the value of any type that can be a pointer to not be nil.
 func NotNil() Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		switch ctx.Value.Kind() {
 		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
 			if ctx.Value.IsNil() {
-				return newError("must not be nil"), nil
+				return newFieldError(ctx, "notnil", nil, "must not be nil"), nil
 			}
 			return nil, nil
 		default:
@@ -535,6 +1054,9 @@ func Or(validators ...Validator) Validator {
 		var warnings []error
 		for _, v := range validators {
 			err, warning := v.Validate(ctx)
+			if isCanceled(err) {
+				return err, nil
+			}
 			if err == nil && warning == nil {
 				return nil, nil
 			}
@@ -550,11 +1072,187 @@ func Or(validators ...Validator) Validator {
 	})
 }
 
+// RequiredIf requires the value to be present, being other than the zero value, when the referenced
+// field is equal to the specified value. See EqualField for the form of a reference.
+func RequiredIf(ref string, value interface{}) Validator {
+	return presenceIf("required_if", ref, value, true, true, "is required when field %q is %v")
+}
+
+// RequiredUnless requires the value to be present, being other than the zero value, unless the
+// referenced field is equal to the specified value. See EqualField for the form of a reference.
+func RequiredUnless(ref string, value interface{}) Validator {
+	return presenceIf("required_unless", ref, value, false, true, "is required unless field %q is %v")
+}
+
+// RequiredWith requires the value to be present, being other than the zero value, when any of the
+// referenced fields are present. See EqualField for the form of a reference.
+func RequiredWith(refs ...string) Validator {
+	return presenceWith("required_with", refs, true, true, "is required when field %q is present")
+}
+
+// RequiredWithout requires the value to be present, being other than the zero value, when any of the
+// referenced fields are absent. See EqualField for the form of a reference.
+func RequiredWithout(refs ...string) Validator {
+	return presenceWith("required_without", refs, false, true, "is required when field %q is absent")
+}
+
+// presenceIf requires the value's presence to match required when the referenced field's equality
+// to the value matches equal.
+func presenceIf(name string, ref string, value interface{}, equal bool, required bool, msg string) Validator {
+	fr, err := parseFieldReference(ref)
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		if err != nil {
+			return err, nil
+		}
+
+		eq, err := fieldEquals(ctx, fr, value)
+		if err != nil {
+			return err, nil
+		}
+
+		if eq == equal && isZero(ctx.Value) == required {
+			return newFieldErrorf(ctx, name, []interface{}{ref, value}, msg, ref, value), nil
+		}
+
+		return nil, nil
+	})
+}
+
+// presenceWith requires the value's presence to match required when any of the referenced fields'
+// presence matches present.
+func presenceWith(name string, refs []string, present bool, required bool, msg string) Validator {
+	var frs []fieldReference
+	var err error
+	for _, ref := range refs {
+		var fr fieldReference
+		fr, err = parseFieldReference(ref)
+		if err != nil {
+			break
+		}
+		frs = append(frs, fr)
+	}
+
+	args := make([]interface{}, len(refs))
+	for i, ref := range refs {
+		args[i] = ref
+	}
+
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		if err != nil {
+			return err, nil
+		}
+
+		if isZero(ctx.Value) != required {
+			return nil, nil
+		}
+
+		for _, fr := range frs {
+			p, err := fieldPresent(ctx, fr)
+			if err != nil {
+				return err, nil
+			}
+
+			if p == present {
+				return newFieldErrorf(ctx, name, args, msg, fr.raw), nil
+			}
+		}
+
+		return nil, nil
+	})
+}
+
+func fieldPresent(ctx Context, fr fieldReference) (bool, error) {
+	val, err := fr.value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return val.IsValid() && !isZero(val), nil
+}
+
+func fieldEquals(ctx Context, fr fieldReference, value interface{}) (bool, error) {
+	val, err := fr.value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	val = indirect(val)
+	if !val.IsValid() || val.Kind() == reflect.Ptr {
+		return false, nil
+	}
+
+	other := reflect.ValueOf(value)
+	if other.Kind() == reflect.String && val.Kind() != reflect.String {
+		v, err := tryParseString(val.Type(), other.String())
+		if err != nil {
+			return false, err
+		}
+		other = reflect.ValueOf(v)
+	}
+
+	r, err := cmp(val, other)
+	if err != nil {
+		return false, err
+	}
+
+	return r == 0, nil
+}
+
+func isPresenceIfAllowed(ctx ResolutionContext, name string, args []string) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, InvalidTagArgumentsError{Message: "2 arguments are required", ValidatorName: name, Args: args}
+	}
+
+	fr, err := parseFieldReference(args[0])
+	if err != nil {
+		return nil, InvalidTagArgumentsError{Message: "first argument must be a field reference", ValidatorName: name, Args: args}
+	}
+
+	t, ok, err := fr.resolveType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// The value is parsed against the field once it's found.
+		return args[1], nil
+	}
+
+	if err = isCmpAllowed(t, name, args[1:]); err != nil {
+		if iae, ok := err.(InvalidTagArgumentsError); ok {
+			iae.Args = args
+			return nil, iae
+		}
+		return nil, err
+	}
+
+	v, _ := tryParseString(t, args[1])
+	return v, nil
+}
+
+func isPresenceWithAllowed(ctx ResolutionContext, name string, args []string) error {
+	if len(args) == 0 {
+		return InvalidTagArgumentsError{Message: "at least 1 argument is required", ValidatorName: name, Args: args}
+	}
+
+	for _, arg := range args {
+		fr, err := parseFieldReference(arg)
+		if err != nil {
+			return InvalidTagArgumentsError{Message: "arguments must be field references", ValidatorName: name, Args: args}
+		}
+
+		if _, _, err = fr.resolveType(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Zero requires the value to be the zero value.
 func Zero() Validator {
 	return ValidatorFunc(func(ctx Context) (error, error) {
 		if !isZero(ctx.Value) {
-			return newErrorf("must be \"%v\"", zeroValue(ctx.Value.Type())), nil
+			return newFieldErrorf(ctx, "zero", nil, "must be \"%v\"", zeroValue(ctx.Value.Type())), nil
 		}
 		return nil, nil
 	})
@@ -607,6 +1305,35 @@ func isCmpAllowed(t reflect.Type, name string, args []string) error {
 		return InvalidTagArgumentsError{Message: "1 argument is required", ValidatorName: name, Args: args}
 	}
 
+	switch t {
+	case tTime:
+		_, err := tryParseString(t, args[0])
+		if err != nil {
+			return InvalidTagArgumentsError{Message: "argument must be an RFC 3339 time or relative to now, such as now+24h", ValidatorName: name, Args: args}
+		}
+		return nil
+	case tDuration:
+		_, err := tryParseString(t, args[0])
+		if err != nil {
+			return InvalidTagArgumentsError{Message: "argument must be a duration, such as 5s, or a number of nanoseconds", ValidatorName: name, Args: args}
+		}
+		return nil
+	}
+
+	if hasCompareMethod(t) {
+		if hasParseArgMethod(t) {
+			_, err := tryParseString(t, args[0])
+			if err != nil {
+				return InvalidTagArgumentsError{Message: "argument could not be parsed: " + err.Error(), ValidatorName: name, Args: args}
+			}
+			return nil
+		}
+
+		if !isPrimitive(t) {
+			return InvalidTagArgumentsError{Message: fmt.Sprintf("%s must have a ParseValidateArg method to parse arguments", t), ValidatorName: name, Args: args}
+		}
+	}
+
 	switch t.Kind() {
 	case reflect.Bool:
 		_, err := tryParseString(t, args[0])
@@ -632,13 +1359,124 @@ func isCmpAllowed(t reflect.Type, name string, args []string) error {
 		return isCmpAllowed(t.Elem(), name, args)
 	case reflect.String:
 	default:
-		return InvalidTagArgumentsError{Message: "only pointers to/or strings, bools, and numbers are allowed", ValidatorName: name, Args: args}
+		return InvalidTagArgumentsError{Message: "only pointers to/or strings, bools, numbers, and time.Times are allowed", ValidatorName: name, Args: args}
 	}
 
 	return nil
 }
 
+func compareField(ref string, name string, msg string, compare compareFunc, pass func(int) bool) Validator {
+	fr, err := parseFieldReference(ref)
+	return ValidatorFunc(func(ctx Context) (error, error) {
+		if err != nil {
+			return err, nil
+		}
+
+		other, err := fr.value(ctx)
+		if err != nil {
+			return err, nil
+		}
+
+		val := indirect(ctx.Value)
+		other = indirect(other)
+		if !other.IsValid() || val.Kind() == reflect.Ptr || other.Kind() == reflect.Ptr {
+			return newFieldErrorf(ctx, name, []interface{}{ref}, "%s field %q", msg, ref), nil
+		}
+
+		r, err := compare(val, other)
+		if err != nil {
+			return err, nil
+		}
+
+		if !pass(r) {
+			return newFieldErrorf(ctx, name, []interface{}{ref}, "%s field %q", msg, ref), nil
+		}
+
+		return nil, nil
+	})
+}
+
+func isFieldCmpAllowed(ctx ResolutionContext, name string, args []string) error {
+	if len(args) != 1 {
+		return InvalidTagArgumentsError{Message: "1 argument is required", ValidatorName: name, Args: args}
+	}
+
+	fr, err := parseFieldReference(args[0])
+	if err != nil {
+		return InvalidTagArgumentsError{Message: "argument must be a field reference", ValidatorName: name, Args: args}
+	}
+
+	other, ok, err := fr.resolveType(ctx)
+	if err != nil {
+		return err
+	}
+
+	kind := cmpKind(ctx.Type)
+	if kind == reflect.Invalid {
+		return InvalidTagArgumentsError{Message: "only pointers to/or strings, bools, numbers, and time.Times are allowed", ValidatorName: name, Args: args}
+	}
+	if ok && (cmpKind(other) != kind || kind == reflect.Interface && indirectType(other) != indirectType(ctx.Type)) {
+		return InvalidTagArgumentsError{Message: "the referenced field must be of a comparable type", ValidatorName: name, Args: args}
+	}
+
+	return nil
+}
+
+// cmpKind returns the kind used to determine whether values of the types can be compared. All
+// numbers share reflect.Float64, types with a Compare method share reflect.Interface though
+// they're only comparable with their own type, and reflect.Invalid is returned for types that
+// aren't comparable.
+func cmpKind(t reflect.Type) reflect.Kind {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == tTime {
+		return reflect.Struct
+	}
+	if hasCompareMethod(t) {
+		return reflect.Interface
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.String:
+		return t.Kind()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	default:
+		return reflect.Invalid
+	}
+}
+
+// cmp compares the values with the Compare method of their type, or otherwise by their kind.
 func cmp(val reflect.Value, other reflect.Value) (int, error) {
+	if m, ok := compareMethod(val.Type()); ok {
+		return callCompare(m, val, other)
+	}
+
+	return cmpValues(val, other)
+}
+
+// cmpValues compares times, or otherwise the values by their kind.
+func cmpValues(val reflect.Value, other reflect.Value) (int, error) {
+	if val.Type() == tTime && other.Type() == tTime {
+		if !val.CanInterface() || !other.CanInterface() {
+			return 0, fmt.Errorf("cannot compare an unexported %s", tTime)
+		}
+
+		v := val.Interface().(time.Time)
+		o := other.Interface().(time.Time)
+		if v.Before(o) {
+			return -1, nil
+		} else if v.Equal(o) {
+			return 0, nil
+		} else {
+			return 1, nil
+		}
+	}
+
 	switch val.Kind() {
 	case reflect.Bool:
 		switch other.Kind() {
@@ -718,6 +1556,16 @@ func cmp(val reflect.Value, other reflect.Value) (int, error) {
 				return 1, nil
 			}
 		}
+	case reflect.Float32, reflect.Float64:
+		v := val.Float()
+		switch other.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmpFloat(v, float64(other.Int())), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmpFloat(v, float64(other.Uint())), nil
+		case reflect.Float32, reflect.Float64:
+			return cmpFloat(v, other.Float()), nil
+		}
 	case reflect.String:
 		v := val.String()
 		switch other.Kind() {
@@ -730,7 +1578,38 @@ func cmp(val reflect.Value, other reflect.Value) (int, error) {
 	return 0, fmt.Errorf("incompatible types for comparision: %s and %s", val.Type(), other.Type())
 }
 
+func cmpFloat(v float64, o float64) int {
+	if v < o {
+		return -1
+	} else if v == o {
+		return 0
+	} else {
+		return 1
+	}
+}
+
 func tryParseString(t reflect.Type, arg string) (interface{}, error) {
+	switch t {
+	case tTime:
+		return parseTimeArg(arg)
+	case tDuration:
+		d, err := time.ParseDuration(arg)
+		if err != nil {
+			// Durations were compared as integers before they were parsed, so a number of
+			// nanoseconds is still accepted.
+			n, nerr := strconv.ParseInt(arg, 10, 64)
+			if nerr != nil {
+				return nil, err
+			}
+			d = time.Duration(n)
+		}
+		return d, nil
+	}
+
+	if hasParseArgMethod(t) {
+		return callParseArg(t, arg)
+	}
+
 	switch t.Kind() {
 	case reflect.Bool:
 		v, err := strconv.ParseBool(arg)
@@ -775,3 +1654,11 @@ func indirect(val reflect.Value) reflect.Value {
 
 	return val
 }
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}